@@ -4,6 +4,12 @@ resource "kind_cluster" "advanced" {
   wait_for_ready = true
   node_image     = "kindest/node:v1.34.0"
 
+  # Readiness gates polled after creation, within the create timeout
+  wait_for {
+    all_nodes_ready = true
+    coredns_ready   = true
+  }
+
   kind_config {
     kind        = "Cluster"
     api_version = "kind.x-k8s.io/v1alpha4"
@@ -32,15 +38,12 @@ resource "kind_cluster" "advanced" {
         read_only      = false
       }
 
-      # Kubeadm config patches
-      kubeadm_config_patches = [
-        <<-EOT
-        kind: InitConfiguration
-        nodeRegistration:
-          kubeletExtraArgs:
-            node-labels: "ingress-ready=true"
-        EOT
-      ]
+      # Kubelet arguments, rendered into kubeadm Init/JoinConfiguration patches
+      kubelet {
+        extra_args = {
+          "node-labels" = "ingress-ready=true"
+        }
+      }
     }
 
     # Worker nodes
@@ -56,6 +59,17 @@ resource "kind_cluster" "advanced" {
     node {
       role = "worker"
 
+      # Labels and taints are updated in place through the Kubernetes API
+      labels = {
+        "tier" = "backend"
+      }
+
+      taints = [{
+        key    = "dedicated"
+        value  = "backend"
+        effect = "NoSchedule"
+      }]
+
       extra_mounts {
         host_path      = "/tmp/kind-data"
         container_path = "/data"
@@ -72,13 +86,27 @@ resource "kind_cluster" "advanced" {
       kube_proxy_mode    = "iptables"
     }
 
-    # Containerd configuration patches
-    containerd_config_patches = [
-      <<-EOT
-      [plugins."io.containerd.grpc.v1.cri".registry.mirrors."localhost:5000"]
-        endpoint = ["http://kind-registry:5000"]
-      EOT
-    ]
+    # Control plane component settings, rendered into a kubeadm ClusterConfiguration patch
+    api_server {
+      extra_args = {
+        "enable-admission-plugins" = "NodeRestriction,PodSecurity"
+      }
+      cert_sans = ["kind.local"]
+    }
+
+    controller_manager {
+      extra_args = {
+        "node-monitor-grace-period" = "20s"
+      }
+    }
+
+    # Registry mirrors, written into the nodes as containerd hosts.toml files
+    containerd {
+      registry_mirror {
+        host      = "localhost:5000"
+        endpoints = ["http://kind-registry:5000"]
+      }
+    }
 
     # Feature gates
     feature_gates = {
@@ -0,0 +1,84 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// apiServerPollInterval is the delay between API server readiness checks.
+	apiServerPollInterval = 2 * time.Second
+	// apiServerProbeTimeout bounds a single API server readiness check.
+	apiServerProbeTimeout = 5 * time.Second
+)
+
+// waitForAPIServer polls the /readyz endpoint of the API server behind config
+// until it reports ready or the timeout expires.
+func waitForAPIServer(ctx context.Context, config *rest.Config, timeout time.Duration) error {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	var lastErr error
+
+	err = wait.PollUntilContextTimeout(ctx, apiServerPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		lastErr = probeAPIServer(ctx, client)
+
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		// The last probe error explains more than the poll timeout
+		if lastErr != nil {
+			err = lastErr
+		}
+
+		return fmt.Errorf("API server at %s not ready after %v: %w", config.Host, timeout, err)
+	}
+
+	return nil
+}
+
+// apiServerReady reports whether the API server behind config currently reports ready.
+func apiServerReady(ctx context.Context, config *rest.Config) bool {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return false
+	}
+
+	return probeAPIServer(ctx, client) == nil
+}
+
+// probeAPIServer performs a single /readyz check.
+func probeAPIServer(ctx context.Context, client kubernetes.Interface) error {
+	probeCtx, cancel := context.WithTimeout(ctx, apiServerProbeTimeout)
+	defer cancel()
+
+	_, err := client.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(probeCtx)
+	if err != nil {
+		return fmt.Errorf("readiness check failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestWaitForAPIServer(t *testing.T) {
+	var probes atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/readyz", r.URL.Path)
+
+		// Report ready on the second probe
+		if probes.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	err := waitForAPIServer(t.Context(), &rest.Config{Host: server.URL}, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), probes.Load())
+}
+
+func TestWaitForAPIServer_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := waitForAPIServer(t.Context(), &rest.Config{Host: server.URL}, 100*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not ready")
+}
+
+func TestAPIServerReady(t *testing.T) {
+	ready := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ready.Close()
+
+	unready := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer unready.Close()
+
+	assert.True(t, apiServerReady(t.Context(), &rest.Config{Host: ready.URL}))
+	assert.False(t, apiServerReady(t.Context(), &rest.Config{Host: unready.URL}))
+	assert.Equal(t, clusterStatusUnready, apiServerStatus(t.Context(), &rest.Config{Host: unready.URL}))
+}
@@ -0,0 +1,409 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/yaml"
+)
+
+// nodeKubectl runs kubectl inside a control-plane node with the admin kubeconfig kubeadm wrote.
+//
+//nolint:gochecknoglobals // constant command prefix
+var nodeKubectl = []string{"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf"}
+
+// ErrOverlappingSubnets is returned when the subnets of two peered clusters overlap.
+//
+//nolint:grouper // false positive
+var ErrOverlappingSubnets = errors.New("overlapping cluster subnets")
+
+// ErrNoSharedNetwork is returned when two peered clusters are not attached to a common container network.
+//
+//nolint:grouper // false positive
+var ErrNoSharedNetwork = errors.New("clusters do not share a container network")
+
+// peeringRoute is a route installed in a node towards the pods of a peered cluster.
+type peeringRoute struct {
+	Node        string
+	Destination string
+	Gateway     string
+}
+
+// podRoute is the pod CIDR of a Kubernetes node and the node address it is reached through.
+type podRoute struct {
+	Destination netip.Prefix
+	Gateway     netip.Addr
+}
+
+// peerCluster describes a cluster taking part in a peering.
+type peerCluster struct {
+	Name           string
+	Nodes          []nodes.Node
+	PodSubnets     []netip.Prefix
+	ServiceSubnets []netip.Prefix
+	PodRoutes      []podRoute
+	Networks       []string
+}
+
+// nodeCommandOutput runs a command inside a node and returns its standard output,
+// failing on a non-zero exit code.
+func (r *kindRuntime) nodeCommandOutput(ctx context.Context, node nodes.Node, command ...string) (string, error) {
+	results, err := r.execInNodes(ctx, []nodes.Node{node}, command)
+	if err != nil {
+		return "", err
+	}
+
+	if results[0].exitCode != 0 {
+		return "", fmt.Errorf("%s exited with code %d on node %s: %s",
+			command[0], results[0].exitCode, node.String(), strings.TrimSpace(results[0].stderr))
+	}
+
+	return results[0].stdout, nil
+}
+
+// peerClusterInfo reads the subnets and the per-node pod CIDRs of a cluster through kubectl
+// inside its control plane. It returns ErrNoMatchingNodes when the cluster does not exist.
+func (r *kindRuntime) peerClusterInfo(ctx context.Context, name string) (*peerCluster, error) {
+	nodeList, err := r.internalNodes(name)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlanes, err := r.selectNodes(nodeList, string(v1alpha4.ControlPlaneRole), "")
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s: %w", name, err)
+	}
+
+	clusterConfiguration, err := r.nodeCommandOutput(ctx, controlPlanes[0], append(slices.Clone(nodeKubectl),
+		"get", "configmap", "kubeadm-config", "--namespace", "kube-system",
+		"--output", "jsonpath={.data.ClusterConfiguration}")...)
+	if err != nil {
+		return nil, err
+	}
+
+	podSubnets, serviceSubnets, err := parseClusterSubnets(clusterConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s: %w", name, err)
+	}
+
+	nodesJSON, err := r.nodeCommandOutput(ctx, controlPlanes[0],
+		append(slices.Clone(nodeKubectl), "get", "nodes", "--output", "json")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var kubeNodes corev1.NodeList
+
+	err = json.Unmarshal([]byte(nodesJSON), &kubeNodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the nodes of cluster %s: %w", name, err)
+	}
+
+	networks, err := r.nodeNetworks(ctx, controlPlanes[0].String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &peerCluster{
+		Name:           name,
+		Nodes:          nodeList,
+		PodSubnets:     podSubnets,
+		ServiceSubnets: serviceSubnets,
+		PodRoutes:      nodePodRoutes(kubeNodes.Items),
+		Networks:       networks,
+	}, nil
+}
+
+// nodeNetworks returns the names of the container networks a node is attached to, sorted.
+func (r *kindRuntime) nodeNetworks(ctx context.Context, node string) ([]string, error) {
+	output, err := r.containerCommand(ctx,
+		"inspect", "--format", "{{range $name, $_ := .NetworkSettings.Networks}}{{$name}} {{end}}", node)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := strings.Fields(output)
+	slices.Sort(networks)
+
+	return networks, nil
+}
+
+// parseClusterSubnets returns the pod and service subnets of a kubeadm ClusterConfiguration.
+func parseClusterSubnets(clusterConfiguration string) ([]netip.Prefix, []netip.Prefix, error) {
+	var config struct {
+		Networking struct {
+			PodSubnet     string `json:"podSubnet"`
+			ServiceSubnet string `json:"serviceSubnet"`
+		} `json:"networking"`
+	}
+
+	err := yaml.Unmarshal([]byte(clusterConfiguration), &config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the kubeadm ClusterConfiguration: %w", err)
+	}
+
+	podSubnets, err := parsePrefixes(config.Networking.PodSubnet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceSubnets, err := parsePrefixes(config.Networking.ServiceSubnet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return podSubnets, serviceSubnets, nil
+}
+
+// parsePrefixes parses a comma-separated list of CIDRs.
+func parsePrefixes(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	for field := range strings.SplitSeq(value, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %q: %w", field, err)
+		}
+
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// nodePodRoutes pairs the pod CIDRs of every node with its internal address of the same family.
+func nodePodRoutes(kubeNodes []corev1.Node) []podRoute {
+	var routes []podRoute
+
+	for _, node := range kubeNodes {
+		podCIDRs := node.Spec.PodCIDRs
+		if len(podCIDRs) == 0 && node.Spec.PodCIDR != "" {
+			podCIDRs = []string{node.Spec.PodCIDR}
+		}
+
+		for _, podCIDR := range podCIDRs {
+			destination, err := netip.ParsePrefix(podCIDR)
+			if err != nil {
+				continue
+			}
+
+			for _, address := range node.Status.Addresses {
+				gateway, addrErr := netip.ParseAddr(address.Address)
+				if address.Type != corev1.NodeInternalIP || addrErr != nil || gateway.Is4() != destination.Addr().Is4() {
+					continue
+				}
+
+				routes = append(routes, podRoute{Destination: destination.Masked(), Gateway: gateway})
+
+				break
+			}
+		}
+	}
+
+	return routes
+}
+
+// sharedNetwork returns an error naming the networks of both clusters when they have none in
+// common, as the routes between them go through the node addresses on a shared network.
+func sharedNetwork(first, second *peerCluster) error {
+	for _, network := range first.Networks {
+		if slices.Contains(second.Networks, network) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: cluster %s is attached to %s and cluster %s to %s",
+		ErrNoSharedNetwork,
+		first.Name, strings.Join(first.Networks, ", "),
+		second.Name, strings.Join(second.Networks, ", "))
+}
+
+// overlappingSubnets returns an error naming the first pair of overlapping pod or service subnets.
+func overlappingSubnets(first, second *peerCluster) error {
+	firstSubnets := slices.Concat(first.PodSubnets, first.ServiceSubnets)
+	secondSubnets := slices.Concat(second.PodSubnets, second.ServiceSubnets)
+
+	for _, firstSubnet := range firstSubnets {
+		for _, secondSubnet := range secondSubnets {
+			if firstSubnet.Overlaps(secondSubnet) {
+				return fmt.Errorf("%w: %s of cluster %s overlaps %s of cluster %s",
+					ErrOverlappingSubnets, firstSubnet, first.Name, secondSubnet, second.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// peeringRoutes returns the routes every node of the cluster needs towards the pods of the peer.
+func peeringRoutes(from, to *peerCluster) []peeringRoute {
+	routes := make([]peeringRoute, 0, len(from.Nodes)*len(to.PodRoutes))
+
+	for _, node := range from.Nodes {
+		for _, route := range to.PodRoutes {
+			routes = append(routes, peeringRoute{
+				Node:        node.String(),
+				Destination: route.Destination.String(),
+				Gateway:     route.Gateway.String(),
+			})
+		}
+	}
+
+	return routes
+}
+
+// clusterPeeringRoutes returns the routes of both directions of a peering, sorted by node
+// and destination so that they compare equal across refreshes.
+func clusterPeeringRoutes(first, second *peerCluster) []peeringRoute {
+	routes := slices.Concat(peeringRoutes(first, second), peeringRoutes(second, first))
+
+	slices.SortFunc(routes, func(a, b peeringRoute) int {
+		return cmp.Or(
+			cmp.Compare(a.Node, b.Node),
+			cmp.Compare(a.Destination, b.Destination),
+			cmp.Compare(a.Gateway, b.Gateway),
+		)
+	})
+
+	return routes
+}
+
+// routesByNode groups routes by the node they are installed in, in a stable order.
+func routesByNode(routes []peeringRoute) ([]string, map[string][]peeringRoute) {
+	grouped := make(map[string][]peeringRoute)
+
+	for _, route := range routes {
+		grouped[route.Node] = append(grouped[route.Node], route)
+	}
+
+	nodeNames := make([]string, 0, len(grouped))
+	for name := range grouped {
+		nodeNames = append(nodeNames, name)
+	}
+
+	slices.Sort(nodeNames)
+
+	return nodeNames, grouped
+}
+
+// routeScript returns a shell script running "ip route <action>" for every route.
+// Deletions ignore routes that are already gone.
+func routeScript(action string, routes []peeringRoute) string {
+	commands := make([]string, 0, len(routes))
+
+	for _, route := range routes {
+		command := fmt.Sprintf("ip route %s %s via %s", action, route.Destination, route.Gateway)
+		if action == "del" {
+			command += " 2>/dev/null || true"
+		}
+
+		commands = append(commands, command)
+	}
+
+	return strings.Join(commands, "\n")
+}
+
+// parseRouteTable returns the gateway of every route in the output of "ip route show".
+func parseRouteTable(output string) map[string]string {
+	table := make(map[string]string)
+
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[1] != "via" {
+			continue
+		}
+
+		table[fields[0]] = fields[2]
+	}
+
+	return table
+}
+
+// applyPeeringRoutes runs "ip route <action>" for the routes inside the nodes of the clusters.
+// Nodes that no longer exist are skipped.
+func (r *kindRuntime) applyPeeringRoutes(
+	ctx context.Context,
+	nodeList []nodes.Node,
+	action string,
+	routes []peeringRoute,
+) error {
+	nodeNames, grouped := routesByNode(routes)
+
+	for _, nodeName := range nodeNames {
+		index := slices.IndexFunc(nodeList, func(node nodes.Node) bool { return node.String() == nodeName })
+		if index < 0 {
+			continue
+		}
+
+		err := r.execInNodesChecked(ctx, nodeList[index:index+1], "sh", "-c", routeScript(action, grouped[nodeName]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// missingPeeringRoutes returns the routes that are not installed in their node.
+func (r *kindRuntime) missingPeeringRoutes(
+	ctx context.Context,
+	nodeList []nodes.Node,
+	routes []peeringRoute,
+) ([]peeringRoute, error) {
+	nodeNames, grouped := routesByNode(routes)
+
+	var missing []peeringRoute
+
+	for _, nodeName := range nodeNames {
+		index := slices.IndexFunc(nodeList, func(node nodes.Node) bool { return node.String() == nodeName })
+		if index < 0 {
+			missing = append(missing, grouped[nodeName]...)
+
+			continue
+		}
+
+		output, err := r.nodeCommandOutput(ctx, nodeList[index], "sh", "-c", "ip route show; ip -6 route show")
+		if err != nil {
+			return nil, err
+		}
+
+		table := parseRouteTable(output)
+
+		for _, route := range grouped[nodeName] {
+			if table[route.Destination] != route.Gateway {
+				missing = append(missing, route)
+			}
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,245 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	kindexec "sigs.k8s.io/kind/pkg/exec"
+)
+
+// routeNode is a node stub that records the commands it runs and prints a route table.
+type routeNode struct {
+	commands *[]string
+	testNode
+
+	routes string
+}
+
+func (n routeNode) CommandContext(ctx context.Context, command string, args ...string) kindexec.Cmd {
+	*n.commands = append(*n.commands, command+" "+strings.Join(args, " "))
+
+	return kindexec.CommandContext(ctx, "printf", "%s", n.routes)
+}
+
+func testPeerCluster(name string, nodeNames []string, podRoutes map[string]string) *peerCluster {
+	cluster := &peerCluster{Name: name}
+
+	for _, nodeName := range nodeNames {
+		cluster.Nodes = append(cluster.Nodes, testNode{name: nodeName})
+	}
+
+	for destination, gateway := range podRoutes {
+		cluster.PodRoutes = append(cluster.PodRoutes, podRoute{
+			Destination: netip.MustParsePrefix(destination),
+			Gateway:     netip.MustParseAddr(gateway),
+		})
+	}
+
+	return cluster
+}
+
+func TestParseClusterSubnets(t *testing.T) {
+	podSubnets, serviceSubnets, err := parseClusterSubnets(`apiVersion: kubeadm.k8s.io/v1beta4
+kind: ClusterConfiguration
+networking:
+  dnsDomain: cluster.local
+  podSubnet: 10.245.0.0/16,fd00:10:245::/56
+  serviceSubnet: 10.97.0.0/16
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.245.0.0/16"),
+		netip.MustParsePrefix("fd00:10:245::/56"),
+	}, podSubnets)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.97.0.0/16")}, serviceSubnets)
+
+	_, _, err = parseClusterSubnets("networking:\n  podSubnet: 10.245.0.0\n")
+	require.Error(t, err)
+
+	_, _, err = parseClusterSubnets("networking: [")
+	require.Error(t, err)
+}
+
+func TestNodePodRoutes(t *testing.T) {
+	kubeNodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "east-control-plane"},
+			Spec:       corev1.NodeSpec{PodCIDR: "10.245.0.0/24", PodCIDRs: []string{"10.245.0.0/24", "fd00:10:245::/64"}},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "east-control-plane"},
+				{Type: corev1.NodeInternalIP, Address: "172.18.0.4"},
+				{Type: corev1.NodeInternalIP, Address: "fc00:f853:ccd:e793::4"},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "east-worker"},
+			Spec:       corev1.NodeSpec{PodCIDR: "10.245.1.0/24"},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "172.18.0.5"},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "east-worker2"},
+		},
+	}
+
+	assert.Equal(t, []podRoute{
+		{Destination: netip.MustParsePrefix("10.245.0.0/24"), Gateway: netip.MustParseAddr("172.18.0.4")},
+		{Destination: netip.MustParsePrefix("fd00:10:245::/64"), Gateway: netip.MustParseAddr("fc00:f853:ccd:e793::4")},
+		{Destination: netip.MustParsePrefix("10.245.1.0/24"), Gateway: netip.MustParseAddr("172.18.0.5")},
+	}, nodePodRoutes(kubeNodes))
+}
+
+func TestOverlappingSubnets(t *testing.T) {
+	east := &peerCluster{
+		Name:           "east",
+		PodSubnets:     []netip.Prefix{netip.MustParsePrefix("10.245.0.0/16")},
+		ServiceSubnets: []netip.Prefix{netip.MustParsePrefix("10.97.0.0/16")},
+	}
+	west := &peerCluster{
+		Name:           "west",
+		PodSubnets:     []netip.Prefix{netip.MustParsePrefix("10.244.0.0/16")},
+		ServiceSubnets: []netip.Prefix{netip.MustParsePrefix("10.96.0.0/16")},
+	}
+
+	require.NoError(t, overlappingSubnets(east, west))
+
+	west.ServiceSubnets = []netip.Prefix{netip.MustParsePrefix("10.245.128.0/20")}
+
+	err := overlappingSubnets(east, west)
+	require.ErrorIs(t, err, ErrOverlappingSubnets)
+	assert.Contains(t, err.Error(), "10.245.0.0/16 of cluster east overlaps 10.245.128.0/20 of cluster west")
+}
+
+func TestSharedNetwork(t *testing.T) {
+	east := &peerCluster{Name: "east", Networks: []string{"dev-net", "kind"}}
+	west := &peerCluster{Name: "west", Networks: []string{"kind"}}
+
+	require.NoError(t, sharedNetwork(east, west))
+
+	west.Networks = []string{"prod-net"}
+
+	err := sharedNetwork(east, west)
+	require.ErrorIs(t, err, ErrNoSharedNetwork)
+	assert.Contains(t, err.Error(), "cluster east is attached to dev-net, kind and cluster west to prod-net")
+}
+
+func TestNodeNetworks(t *testing.T) {
+	runtime, calls := fakeRuntimeCLI(t, map[string]string{"inspect --format": "kind dev-net \n"})
+
+	networks, err := runtime.nodeNetworks(t.Context(), "east-control-plane")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dev-net", "kind"}, networks)
+	assert.Len(t, calls(), 1)
+}
+
+func TestClusterPeeringRoutes(t *testing.T) {
+	east := testPeerCluster("east", []string{"east-worker", "east-control-plane"},
+		map[string]string{"10.245.0.0/24": "172.18.0.4"})
+	west := testPeerCluster("west", []string{"west-control-plane"},
+		map[string]string{"10.244.0.0/24": "172.18.0.2"})
+
+	assert.Equal(t, []peeringRoute{
+		{Node: "east-control-plane", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+		{Node: "east-worker", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+		{Node: "west-control-plane", Destination: "10.245.0.0/24", Gateway: "172.18.0.4"},
+	}, clusterPeeringRoutes(east, west))
+}
+
+func TestRouteScript(t *testing.T) {
+	routes := []peeringRoute{
+		{Node: "east-worker", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+		{Node: "east-worker", Destination: "10.244.1.0/24", Gateway: "172.18.0.3"},
+	}
+
+	assert.Equal(t,
+		"ip route replace 10.244.0.0/24 via 172.18.0.2\nip route replace 10.244.1.0/24 via 172.18.0.3",
+		routeScript("replace", routes))
+	assert.Equal(t,
+		"ip route del 10.244.0.0/24 via 172.18.0.2 2>/dev/null || true\nip route del 10.244.1.0/24 via 172.18.0.3 2>/dev/null || true",
+		routeScript("del", routes))
+}
+
+func TestParseRouteTable(t *testing.T) {
+	table := parseRouteTable(`default via 172.18.0.1 dev eth0
+10.244.0.0/24 via 172.18.0.2 dev eth0
+10.245.1.0/24 dev veth1234 scope host
+172.18.0.0/16 dev eth0 proto kernel scope link src 172.18.0.4
+fd00:10:244::/64 via fc00:f853:ccd:e793::2 dev eth0 metric 1024 pref medium
+`)
+
+	assert.Equal(t, map[string]string{
+		"default":          "172.18.0.1",
+		"10.244.0.0/24":    "172.18.0.2",
+		"fd00:10:244::/64": "fc00:f853:ccd:e793::2",
+	}, table)
+}
+
+func TestMissingPeeringRoutes(t *testing.T) {
+	var commands []string
+
+	nodeList := []nodes.Node{
+		routeNode{testNode: testNode{name: "east-worker"}, commands: &commands, routes: "10.244.0.0/24 via 172.18.0.2 dev eth0\n"},
+	}
+	routes := []peeringRoute{
+		{Node: "east-worker", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+		{Node: "east-worker", Destination: "10.244.1.0/24", Gateway: "172.18.0.3"},
+		{Node: "east-worker2", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+	}
+
+	runtime := &kindRuntime{env: map[string]string{}}
+
+	missing, err := runtime.missingPeeringRoutes(t.Context(), nodeList, routes)
+	require.NoError(t, err)
+
+	assert.Equal(t, routes[1:], missing)
+	assert.Equal(t, []string{"sh -c ip route show; ip -6 route show"}, commands)
+}
+
+func TestApplyPeeringRoutes(t *testing.T) {
+	var commands []string
+
+	nodeList := []nodes.Node{
+		routeNode{testNode: testNode{name: "east-worker"}, commands: &commands},
+		routeNode{testNode: testNode{name: "west-worker"}, commands: &commands},
+	}
+	routes := []peeringRoute{
+		{Node: "west-worker", Destination: "10.245.0.0/24", Gateway: "172.18.0.4"},
+		{Node: "east-worker", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+		{Node: "east-worker2", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+	}
+
+	runtime := &kindRuntime{env: map[string]string{}}
+
+	require.NoError(t, runtime.applyPeeringRoutes(t.Context(), nodeList, "replace", routes))
+
+	assert.Equal(t, []string{
+		"sh -c ip route replace 10.244.0.0/24 via 172.18.0.2",
+		"sh -c ip route replace 10.245.0.0/24 via 172.18.0.4",
+	}, commands, "nodes that no longer exist are skipped")
+}
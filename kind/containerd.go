@@ -0,0 +1,149 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// containerdCertsDir is the containerd registry host configuration directory inside the nodes.
+	containerdCertsDir = "/etc/containerd/certs.d"
+	// containerdRegistryConfigPatch points the containerd CRI plugin at containerdCertsDir.
+	containerdRegistryConfigPatch = `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "` + containerdCertsDir + `"
+`
+	// registryHostsFile is the per-registry containerd host configuration file name.
+	registryHostsFile = "hosts.toml"
+	// registryCAFile is the per-registry CA certificate file name.
+	registryCAFile = "ca.crt"
+)
+
+// ErrInvalidRegistryMirror is returned when a registry_mirror block is invalid.
+//
+//nolint:grouper // false positive
+var ErrInvalidRegistryMirror = errors.New("invalid registry_mirror")
+
+// registryMirror describes a containerd registry mirror configuration.
+type registryMirror struct {
+	Host       string
+	CAFile     string
+	Endpoints  []string
+	SkipVerify bool
+}
+
+// flattenRegistryMirrors extracts the registry mirrors of a kind_config containerd block.
+func flattenRegistryMirrors(kindConfig map[string]any) []registryMirror {
+	containerdConfig := getMap(kindConfig, "containerd")
+
+	mirrorMaps := getMapSlice(containerdConfig, "registry_mirror")
+	mirrors := make([]registryMirror, 0, len(mirrorMaps))
+
+	for _, mirrorMap := range mirrorMaps {
+		mirrors = append(mirrors, registryMirror{
+			Host:       getString(mirrorMap, "host"),
+			Endpoints:  getStringSlice(mirrorMap, "endpoints"),
+			SkipVerify: getBool(mirrorMap, "skip_verify"),
+			CAFile:     getString(mirrorMap, "ca_file"),
+		})
+	}
+
+	return mirrors
+}
+
+// validate checks that the mirror has a host and only absolute http(s) endpoints.
+func (m registryMirror) validate() error {
+	if m.Host == "" {
+		return fmt.Errorf("%w: host must be set", ErrInvalidRegistryMirror)
+	}
+
+	if len(m.Endpoints) == 0 {
+		return fmt.Errorf("%w: %s must have at least one endpoint", ErrInvalidRegistryMirror, m.Host)
+	}
+
+	for _, endpoint := range m.Endpoints {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf(
+				"%w: endpoint %q of %s must be an http:// or https:// URL",
+				ErrInvalidRegistryMirror,
+				endpoint,
+				m.Host,
+			)
+		}
+	}
+
+	return nil
+}
+
+// dir returns the containerd host configuration directory of the mirrored registry.
+func (m registryMirror) dir() string {
+	return path.Join(containerdCertsDir, m.Host)
+}
+
+// hostsTOML renders the containerd hosts.toml for the mirror. caPath is the
+// in-node CA certificate path, or empty when the mirror has no custom CA.
+func (m registryMirror) hostsTOML(caPath string) string {
+	var builder strings.Builder
+
+	for _, endpoint := range m.Endpoints {
+		fmt.Fprintf(&builder, "[host.%s]\n", strconv.Quote(endpoint))
+		builder.WriteString("  capabilities = [\"pull\", \"resolve\"]\n")
+
+		if m.SkipVerify {
+			builder.WriteString("  skip_verify = true\n")
+		}
+
+		if caPath != "" {
+			fmt.Fprintf(&builder, "  ca = %s\n", strconv.Quote(caPath))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// registryMirrorFiles returns the files, keyed by in-node path, configuring the mirrors.
+// CA certificates are read from the host and copied next to the mirror's hosts.toml.
+func registryMirrorFiles(mirrors []registryMirror) (map[string]string, error) {
+	files := make(map[string]string, len(mirrors))
+
+	for _, mirror := range mirrors {
+		var caPath string
+
+		if mirror.CAFile != "" {
+			caData, err := os.ReadFile(mirror.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read ca_file of %s: %w", mirror.Host, err)
+			}
+
+			caPath = path.Join(mirror.dir(), registryCAFile)
+			files[caPath] = string(caData)
+		}
+
+		files[path.Join(mirror.dir(), registryHostsFile)] = mirror.hostsTOML(caPath)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,148 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	toml "github.com/pelletier/go-toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMirrorEndpoint is a registry mirror endpoint used in tests.
+const testMirrorEndpoint = "http://kind-registry:5000"
+
+func TestFlattenRegistryMirrors(t *testing.T) {
+	assert.Empty(t, flattenRegistryMirrors(nil), "unset containerd block yields no mirrors")
+
+	mirrors := flattenRegistryMirrors(map[string]any{
+		"containerd": map[string]any{
+			"registry_mirror": []any{
+				map[string]any{
+					"host":        "docker.io",
+					"endpoints":   []any{testMirrorEndpoint},
+					"skip_verify": true,
+					"ca_file":     "/tmp/ca.crt",
+				},
+			},
+		},
+	})
+
+	require.Len(t, mirrors, 1)
+	assert.Equal(t, registryMirror{
+		Host:       "docker.io",
+		Endpoints:  []string{testMirrorEndpoint},
+		SkipVerify: true,
+		CAFile:     "/tmp/ca.crt",
+	}, mirrors[0])
+}
+
+func TestRegistryMirrorValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mirror  registryMirror
+		wantErr bool
+	}{
+		{
+			name:   "valid mirror",
+			mirror: registryMirror{Host: "docker.io", Endpoints: []string{testMirrorEndpoint}},
+		},
+		{
+			name:    "missing host",
+			mirror:  registryMirror{Endpoints: []string{testMirrorEndpoint}},
+			wantErr: true,
+		},
+		{
+			name:    "missing endpoints",
+			mirror:  registryMirror{Host: "docker.io"},
+			wantErr: true,
+		},
+		{
+			name:    "endpoint without scheme",
+			mirror:  registryMirror{Host: "docker.io", Endpoints: []string{"kind-registry:5000"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mirror.validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidRegistryMirror)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRegistryMirrorHostsTOML(t *testing.T) {
+	mirror := registryMirror{
+		Host:       "localhost:5000",
+		Endpoints:  []string{testMirrorEndpoint, "https://mirror.example.com"},
+		SkipVerify: true,
+	}
+
+	tree, err := toml.Load(mirror.hostsTOML("/etc/containerd/certs.d/localhost:5000/ca.crt"))
+	require.NoError(t, err, "hosts.toml should be valid TOML")
+
+	hosts, ok := tree.Get("host").(*toml.Tree)
+	require.True(t, ok, "hosts.toml should have a host table")
+	assert.ElementsMatch(t, []string{testMirrorEndpoint, "https://mirror.example.com"}, hosts.Keys())
+
+	endpoint, ok := hosts.GetPath([]string{testMirrorEndpoint}).(*toml.Tree)
+	require.True(t, ok)
+	assert.Equal(t, true, endpoint.Get("skip_verify"))
+	assert.Equal(t, "/etc/containerd/certs.d/localhost:5000/ca.crt", endpoint.Get("ca"))
+	assert.Equal(t, []any{"pull", "resolve"}, endpoint.Get("capabilities"))
+}
+
+func TestRegistryMirrorFiles(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caPath, []byte("PEM"), 0o600))
+
+	files, err := registryMirrorFiles([]registryMirror{
+		{Host: "docker.io", Endpoints: []string{testMirrorEndpoint}},
+		{Host: "ghcr.io", Endpoints: []string{testMirrorEndpoint}, CAFile: caPath},
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, files, 3)
+	assert.Contains(t, files, "/etc/containerd/certs.d/docker.io/hosts.toml")
+	assert.Equal(t, "PEM", files["/etc/containerd/certs.d/ghcr.io/ca.crt"])
+	assert.Contains(
+		t,
+		files["/etc/containerd/certs.d/ghcr.io/hosts.toml"],
+		`ca = "/etc/containerd/certs.d/ghcr.io/ca.crt"`,
+	)
+
+	_, err = registryMirrorFiles([]registryMirror{
+		{Host: "docker.io", Endpoints: []string{testMirrorEndpoint}, CAFile: filepath.Join(t.TempDir(), "missing")},
+	})
+	require.Error(t, err, "missing CA files should be reported")
+}
+
+func TestContainerdRegistryConfigPatch(t *testing.T) {
+	normalized, err := normalizeToml(containerdRegistryConfigPatch)
+	require.NoError(t, err, "patch should be valid TOML")
+	assert.Contains(t, normalized, containerdCertsDir)
+}
@@ -0,0 +1,237 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &NetworkDataSource{}
+	_ datasource.DataSourceWithConfigure = &NetworkDataSource{}
+
+	// networkSubnetType is the element type of the subnets attribute.
+	//
+	//nolint:gochecknoglobals // constant attribute type
+	networkSubnetType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"subnet":  types.StringType,
+		"gateway": types.StringType,
+	}}
+)
+
+// NewNetworkDataSource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewNetworkDataSource() datasource.DataSource {
+	return &NetworkDataSource{}
+}
+
+// NetworkDataSource is the data source implementation.
+// NetworkDataSourceModel describes the data source data model.
+type (
+	NetworkDataSource struct {
+		// runtime holds the provider-level runtime settings used to reach the container runtime
+		runtime runtimeSettings
+	}
+
+	NetworkDataSourceModel struct {
+		ID          types.String `tfsdk:"id"`
+		Name        types.String `tfsdk:"name"`
+		Runtime     types.String `tfsdk:"runtime"`
+		Subnets     types.List   `tfsdk:"subnets"`
+		IPv4Subnet  types.String `tfsdk:"ipv4_subnet"`
+		IPv4Gateway types.String `tfsdk:"ipv4_gateway"`
+		IPv6Subnet  types.String `tfsdk:"ipv6_subnet"`
+		IPv6Gateway types.String `tfsdk:"ipv6_gateway"`
+		MTU         types.Int64  `tfsdk:"mtu"`
+		Containers  types.List   `tfsdk:"containers"`
+	}
+)
+
+// Configure adds the provider configured client to the data source.
+func (networkDataSource *NetworkDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	networkDataSource.runtime = *settings
+}
+
+// Metadata returns the data source type name.
+func (*NetworkDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+// Schema defines the schema for the data source.
+func (*NetworkDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Reads an existing container network, such as the shared 'kind' network, to find address ranges for MetalLB or cloud-provider-kind.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the network in the container runtime.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the network (ex: kind).",
+			},
+			"runtime": schema.StringAttribute{
+				Optional:    true,
+				Description: "Container runtime provider: 'docker', 'podman', or 'nerdctl'. Auto-detected if not set.",
+			},
+			"subnets": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Every subnet of the network with its gateway.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subnet":  schema.StringAttribute{Computed: true, Description: "Subnet in CIDR notation."},
+						"gateway": schema.StringAttribute{Computed: true, Description: "Gateway of the subnet."},
+					},
+				},
+			},
+			"ipv4_subnet": schema.StringAttribute{
+				Computed:    true,
+				Description: "First IPv4 subnet of the network.",
+			},
+			"ipv4_gateway": schema.StringAttribute{
+				Computed:    true,
+				Description: "Gateway of the first IPv4 subnet.",
+			},
+			"ipv6_subnet": schema.StringAttribute{
+				Computed:    true,
+				Description: "First IPv6 subnet of the network, empty when IPv6 is not enabled.",
+			},
+			"ipv6_gateway": schema.StringAttribute{
+				Computed:    true,
+				Description: "Gateway of the first IPv6 subnet.",
+			},
+			"mtu": schema.Int64Attribute{
+				Computed:    true,
+				Description: "MTU of the network, null when the runtime default is used.",
+			},
+			"containers": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Names of the containers attached to the network, sorted.",
+			},
+		},
+	}
+}
+
+// Read describes the network.
+func (networkDataSource *NetworkDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data NetworkDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := newKindRuntime(data.Runtime.ValueString(), networkDataSource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	info, err := runtime.inspectNetwork(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading network", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(setNetworkDataSourceModel(ctx, &data, info)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setNetworkDataSourceModel records the description of the network.
+func setNetworkDataSourceModel(ctx context.Context, data *NetworkDataSourceModel, info *networkInfo) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ipv4 := info.subnet(false)
+	ipv6 := info.subnet(true)
+
+	data.ID = types.StringValue(info.ID)
+	data.IPv4Subnet = types.StringValue(ipv4.Subnet)
+	data.IPv4Gateway = types.StringValue(ipv4.Gateway)
+	data.IPv6Subnet = types.StringValue(ipv6.Subnet)
+	data.IPv6Gateway = types.StringValue(ipv6.Gateway)
+
+	data.MTU = types.Int64Null()
+	if info.MTU != 0 {
+		data.MTU = types.Int64Value(int64(info.MTU))
+	}
+
+	subnets := make([]attr.Value, 0, len(info.Subnets))
+
+	for _, subnet := range info.Subnets {
+		element, objectDiags := types.ObjectValue(networkSubnetType.AttrTypes, map[string]attr.Value{
+			"subnet":  types.StringValue(subnet.Subnet),
+			"gateway": types.StringValue(subnet.Gateway),
+		})
+		diags.Append(objectDiags...)
+
+		subnets = append(subnets, element)
+	}
+
+	var listDiags diag.Diagnostics
+
+	data.Subnets, listDiags = types.ListValue(networkSubnetType, subnets)
+	diags.Append(listDiags...)
+
+	data.Containers, listDiags = types.ListValueFrom(ctx, types.StringType, info.Containers)
+	diags.Append(listDiags...)
+
+	return diags
+}
@@ -0,0 +1,63 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkDataSource_Schema(t *testing.T) {
+	metadata := &datasource.MetadataResponse{}
+	(&NetworkDataSource{}).Metadata(t.Context(), datasource.MetadataRequest{ProviderTypeName: "kind"}, metadata)
+	assert.Equal(t, "kind_network", metadata.TypeName)
+
+	resp := &datasource.SchemaResponse{}
+	(&NetworkDataSource{}).Schema(t.Context(), datasource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["name"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["containers"].IsComputed())
+}
+
+func TestSetNetworkDataSourceModel(t *testing.T) {
+	info, err := parseNetworkInspect(podmanNetworkInspect)
+	require.NoError(t, err)
+
+	info.Containers = []string{"dev-control-plane"}
+
+	data := NetworkDataSourceModel{Name: types.StringValue("kind")}
+	require.False(t, setNetworkDataSourceModel(t.Context(), &data, info).HasError())
+
+	assert.Equal(t, types.StringValue("9a8b7c6d"), data.ID)
+	assert.Equal(t, types.StringValue("10.89.0.0/24"), data.IPv4Subnet)
+	assert.Equal(t, types.StringValue("10.89.0.1"), data.IPv4Gateway)
+	assert.Equal(t, types.StringValue(""), data.IPv6Subnet)
+	assert.Equal(t, types.Int64Value(1400), data.MTU)
+	assert.Equal(t, []any{map[string]any{"subnet": "10.89.0.0/24", "gateway": "10.89.0.1"}}, listToSlice(data.Subnets))
+	assert.Equal(t, []string{"dev-control-plane"}, listToStringSlice(data.Containers))
+
+	info.MTU = 0
+	require.False(t, setNetworkDataSourceModel(t.Context(), &data, info).HasError())
+	assert.True(t, data.MTU.IsNull())
+}
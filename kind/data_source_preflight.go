@@ -0,0 +1,182 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PreflightDataSource{}
+	_ datasource.DataSourceWithConfigure = &PreflightDataSource{}
+)
+
+// NewPreflightDataSource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewPreflightDataSource() datasource.DataSource {
+	return &PreflightDataSource{}
+}
+
+// PreflightDataSource is the data source implementation.
+// PreflightDataSourceModel describes the data source data model.
+type (
+	PreflightDataSource struct {
+		// runtime holds the provider-level runtime settings used to reach the container runtime
+		runtime runtimeSettings
+	}
+
+	PreflightDataSourceModel struct {
+		ID        types.String `tfsdk:"id"`
+		Runtime   types.String `tfsdk:"runtime"`
+		NodeImage types.String `tfsdk:"node_image"`
+		Passed    types.Bool   `tfsdk:"passed"`
+		Checks    types.List   `tfsdk:"checks"`
+	}
+)
+
+// Configure adds the provider configured client to the data source.
+func (preflightDataSource *PreflightDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	preflightDataSource.runtime = *settings
+}
+
+// Metadata returns the data source type name.
+func (*PreflightDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_preflight"
+}
+
+// Schema defines the schema for the data source.
+func (*PreflightDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Runs the preflight checks kind_cluster runs before creating a cluster: runtime CLI and daemon, cgroup version, cgroup delegation of rootless runtimes, inotify limits and free disk space. Failed checks do not fail the read, use passed in a precondition to enforce them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The runtime CLI the checks ran against.",
+			},
+			"runtime": schema.StringAttribute{
+				Optional:    true,
+				Description: "Container runtime provider: 'docker', 'podman', or 'nerdctl'. Auto-detected if not set.",
+			},
+			"node_image": schema.StringAttribute{
+				Optional:    true,
+				Description: "Node image the cluster will use, cgroup v1 only fails for Kubernetes versions that refuse it. Defaults to the kind_cluster default.",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether no check failed. Warnings do not count as failures.",
+			},
+			"checks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Outcome of every check.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":        schema.StringAttribute{Computed: true, Description: "Name of the check (ex: inotify_max_user_instances)."},
+						"status":      schema.StringAttribute{Computed: true, Description: "Outcome of the check: 'pass', 'warn', 'fail' or 'skip'."},
+						"message":     schema.StringAttribute{Computed: true, Description: "What the check found."},
+						"remediation": schema.StringAttribute{Computed: true, Description: "How to fix a failure or a warning, empty otherwise."},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read runs the checks.
+func (preflightDataSource *PreflightDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data PreflightDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := newKindRuntime(data.Runtime.ValueString(), preflightDataSource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	remoteHost, err := runtime.settings.remoteHost(ctx, runtime.name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error resolving runtime host", err.Error())
+
+		return
+	}
+
+	nodeImage := data.NodeImage.ValueString()
+	if nodeImage == "" {
+		nodeImage = defaultNodeImage
+	}
+
+	resp.Diagnostics.Append(setPreflightDataSourceModel(&data, runtime.preflight(ctx, remoteHost, nodeImage))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setPreflightDataSourceModel records the outcome of the checks.
+func setPreflightDataSourceModel(data *PreflightDataSourceModel, checks []preflightCheck) diag.Diagnostics {
+	binary, _ := runtimeBinary(data.Runtime.ValueString())
+
+	data.ID = types.StringValue(binary)
+	data.Passed = types.BoolValue(!preflightDiagnostics(checks).HasError())
+
+	var diags diag.Diagnostics
+
+	data.Checks, diags = preflightChecksValue(checks)
+
+	return diags
+}
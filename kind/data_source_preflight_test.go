@@ -0,0 +1,64 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightDataSource_Schema(t *testing.T) {
+	metadata := &datasource.MetadataResponse{}
+	(&PreflightDataSource{}).Metadata(t.Context(), datasource.MetadataRequest{ProviderTypeName: "kind"}, metadata)
+	assert.Equal(t, "kind_preflight", metadata.TypeName)
+
+	resp := &datasource.SchemaResponse{}
+	(&PreflightDataSource{}).Schema(t.Context(), datasource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["runtime"].IsOptional())
+	assert.True(t, resp.Schema.Attributes["passed"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["checks"].IsComputed())
+}
+
+func TestSetPreflightDataSourceModel(t *testing.T) {
+	data := PreflightDataSourceModel{Runtime: types.StringValue("podman")}
+
+	require.False(t, setPreflightDataSourceModel(&data, []preflightCheck{
+		{Name: preflightRuntimeCLI, Status: preflightStatusPass, Message: "Found podman."},
+		{Name: preflightInotifyWatches, Status: preflightStatusWarn, Message: "low", Remediation: "raise"},
+	}).HasError())
+
+	assert.Equal(t, types.StringValue("podman"), data.ID)
+	assert.Equal(t, types.BoolValue(true), data.Passed)
+	assert.Equal(t, []any{
+		map[string]any{"name": preflightRuntimeCLI, "status": preflightStatusPass, "message": "Found podman.", "remediation": ""},
+		map[string]any{"name": preflightInotifyWatches, "status": preflightStatusWarn, "message": "low", "remediation": "raise"},
+	}, listToSlice(data.Checks))
+
+	require.False(t, setPreflightDataSourceModel(&data, []preflightCheck{
+		{Name: preflightRuntimeDaemon, Status: preflightStatusFail, Message: "down", Remediation: "start it"},
+	}).HasError())
+
+	assert.Equal(t, types.BoolValue(false), data.Passed)
+}
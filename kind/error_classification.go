@@ -0,0 +1,242 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	kindexec "sigs.k8s.io/kind/pkg/exec"
+)
+
+const (
+	// Failure categories reported in diagnostics and in the error_category log field.
+	errorCategoryImagePull             = "image_pull"
+	errorCategoryPortInUse             = "port_in_use"
+	errorCategoryInsufficientResources = "insufficient_resources"
+	errorCategoryKubeadmTimeout        = "kubeadm_timeout"
+	errorCategoryNodeExited            = "node_exited"
+	errorCategoryRuntimeUnreachable    = "runtime_unreachable"
+	errorCategoryTimeout               = "timeout"
+	errorCategoryUnknown               = "unknown"
+
+	// excerptContext is the number of lines kept before the first matching line of the kind
+	// output, excerptLines the number of lines of the excerpt.
+	excerptContext = 3
+	excerptLines   = 15
+)
+
+// errorRule maps the errors of one failure category to a summary and a remediation.
+type errorRule struct {
+	sentinels   []error
+	category    string
+	summary     string
+	remediation string
+	// patterns are matched, lower-cased, against the error and the output of the failed command
+	patterns []string
+	// permanent failures are not retried, they fail the same way on every attempt
+	permanent bool
+}
+
+// classifiedError is an error of kind or of the runtime with its failure category.
+type classifiedError struct {
+	Category    string
+	Summary     string
+	Remediation string
+	// Excerpt holds the lines of the kind output around the cause, empty without output
+	Excerpt   string
+	Permanent bool
+}
+
+// errorRules are tried in order, so specific causes come before the generic symptoms they
+// produce (ex: an image pull failure also fails kubeadm init).
+//
+//nolint:gochecknoglobals // constant lookup table
+var errorRules = []errorRule{
+	{
+		category: errorCategoryTimeout,
+		summary:  "Operation timed out",
+		remediation: "Raise the matching timeouts of the resource, or check the runtime for stuck " +
+			"containers (ex: docker ps --filter label=" + kindClusterLabel + ").",
+		sentinels: []error{errDeleteTimeout, context.DeadlineExceeded},
+	},
+	{
+		category: errorCategoryRuntimeUnreachable,
+		summary:  "Container runtime unreachable",
+		remediation: "Start the container runtime, check docker_host and docker_context, and make sure " +
+			"the Terraform process may use the runtime socket (ex: membership of the docker group).",
+		patterns: []string{
+			"cannot connect to the docker daemon",
+			"is the docker daemon running",
+			"error during connect",
+			"permission denied while trying to connect",
+			"cannot connect to podman",
+			"failed to connect to the docker api",
+			"no container runtime found",
+		},
+		permanent: true,
+	},
+	{
+		category: errorCategoryPortInUse,
+		summary:  "Host port already in use",
+		remediation: "Free the host port, pick another host_port or api_server_port, or set host_port " +
+			"to 0 to let the runtime pick a free port.",
+		patterns: []string{
+			"address already in use",
+			"port is already allocated",
+			"ports are not available",
+		},
+		permanent: true,
+	},
+	{
+		category: errorCategoryImagePull,
+		summary:  "Node image pull failed",
+		remediation: "Check that node_image exists and is spelled right, log in to the registry for private " +
+			"images, and check the proxy settings or the registry rate limits.",
+		patterns: []string{
+			"failed to pull image",
+			"error pulling image",
+			"pull access denied",
+			"manifest unknown",
+			"toomanyrequests",
+			"failed to resolve reference",
+		},
+	},
+	{
+		category: errorCategoryInsufficientResources,
+		summary:  "Insufficient host resources",
+		remediation: "Free disk space and memory on the runtime host, raise the memory of the runtime VM, " +
+			"or raise the fs.inotify.max_user_instances and fs.inotify.max_user_watches limits. " +
+			"The kind_preflight data source reports the host limits.",
+		patterns: []string{
+			"no space left on device",
+			"disk quota exceeded",
+			"cannot allocate memory",
+			"out of memory",
+			"oomkilled",
+			"too many open files",
+		},
+	},
+	{
+		category: errorCategoryKubeadmTimeout,
+		summary:  "Control plane did not come up",
+		remediation: "kubeadm timed out waiting for the control plane, usually because the kubelet cannot " +
+			"start. Check the cgroup setup with the kind_preflight data source, and the kubeadm and " +
+			"kubelet patches of kind_config.",
+		patterns: []string{
+			"failed to init node with kubeadm",
+			"couldn't initialize a kubernetes cluster",
+			"wait-control-plane",
+			"kubelet-check",
+			"timed out waiting for the condition",
+		},
+	},
+	{
+		category: errorCategoryNodeExited,
+		summary:  "Node container exited",
+		remediation: "A node container stopped while it booted, most often on cgroup v1 hosts, with rootless " +
+			"runtimes lacking cgroup delegation, or on low inotify limits. The kind_preflight data source " +
+			"checks all three.",
+		patterns: []string{
+			"could not find a log line that matches",
+			"container is not running",
+			"is not running",
+			"exited with code",
+		},
+	},
+}
+
+// classifyError maps an error of kind or of the runtime to its failure category.
+func classifyError(err error) classifiedError {
+	output := ""
+
+	var runErr *kindexec.RunError
+	if errors.As(err, &runErr) {
+		output = string(runErr.Output)
+	}
+
+	text := strings.ToLower(err.Error() + "\n" + output)
+
+	for _, rule := range errorRules {
+		if !slices.ContainsFunc(rule.sentinels, func(sentinel error) bool { return errors.Is(err, sentinel) }) &&
+			!slices.ContainsFunc(rule.patterns, func(pattern string) bool { return strings.Contains(text, pattern) }) {
+			continue
+		}
+
+		return classifiedError{
+			Category:    rule.category,
+			Summary:     rule.summary,
+			Remediation: rule.remediation,
+			Excerpt:     logExcerpt(output, rule.patterns),
+			Permanent:   rule.permanent,
+		}
+	}
+
+	return classifiedError{
+		Category: errorCategoryUnknown,
+		Excerpt:  logExcerpt(output, nil),
+	}
+}
+
+// logExcerpt returns the lines of the output around the first line matching a pattern,
+// or its last lines when none matches.
+func logExcerpt(output string, patterns []string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) == 1 && strings.TrimSpace(lines[0]) == "" {
+		return ""
+	}
+
+	start := max(len(lines)-excerptLines, 0)
+
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+		if slices.ContainsFunc(patterns, func(pattern string) bool { return strings.Contains(lower, pattern) }) {
+			start = max(i-excerptContext, 0)
+
+			break
+		}
+	}
+
+	return strings.Join(lines[start:min(start+excerptLines, len(lines))], "\n")
+}
+
+// detail returns the diagnostic detail for the error: the message, the kind output excerpt
+// and the remediation.
+func (c classifiedError) detail(message string) string {
+	parts := []string{message}
+
+	if c.Excerpt != "" {
+		parts = append(parts, "kind output:\n"+c.Excerpt)
+	}
+
+	if c.Remediation != "" {
+		parts = append(parts, c.Remediation)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// diagnosticSummary returns the diagnostic summary for an operation that failed with the error.
+func (c classifiedError) diagnosticSummary(fallback string) string {
+	if c.Summary == "" {
+		return fallback
+	}
+
+	return fallback + ": " + c.Summary
+}
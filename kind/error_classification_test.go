@@ -0,0 +1,156 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kinderrors "sigs.k8s.io/kind/pkg/errors"
+	kindexec "sigs.k8s.io/kind/pkg/exec"
+)
+
+// kubeadmInitOutput is a trimmed kubeadm init failure as kind reports it.
+const kubeadmInitOutput = `I1018 10:00:00.000000     249 initconfiguration.go:255] loading configuration from "/kind/kubeadm.conf"
+[init] Using Kubernetes version: v1.34.0
+[preflight] Running pre-flight checks
+[kubelet-start] Starting the kubelet
+[wait-control-plane] Waiting for the kubelet to boot up the control plane as static Pods
+[kubelet-check] The kubelet is not healthy after 4m0s
+Unfortunately, an error has occurred, likely caused by:
+	- The kubelet is not running
+couldn't initialize a Kubernetes cluster
+`
+
+// runFailure returns the error kind reports when a command it runs fails with output.
+func runFailure(message, output string) error {
+	return kinderrors.Wrap(&kindexec.RunError{
+		Command: []string{"docker", "exec", "dev-control-plane", "kubeadm", "init"},
+		Output:  []byte(output),
+		Inner:   errors.New("exit status 1"),
+	}, message)
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err       error
+		name      string
+		category  string
+		permanent bool
+	}{
+		{
+			name:     "image pull",
+			err:      errors.New("failed to ensure node image: failed to pull image \"kindest/node:v9.9.9\""),
+			category: errorCategoryImagePull,
+		},
+		{
+			name: "port in use",
+			err: runFailure("failed to create cluster: command \"docker run\" failed",
+				"docker: Error response from daemon: driver failed programming external connectivity: "+
+					"Bind for 0.0.0.0:80 failed: port is already allocated."),
+			category:  errorCategoryPortInUse,
+			permanent: true,
+		},
+		{
+			name:     "node exited",
+			err:      errors.New("failed to create cluster: could not find a log line that matches \"Reached target .*Multi-User System.*\""),
+			category: errorCategoryNodeExited,
+		},
+		{
+			name:     "kubeadm init timeout",
+			err:      runFailure("failed to init node with kubeadm", kubeadmInitOutput),
+			category: errorCategoryKubeadmTimeout,
+		},
+		{
+			name:     "insufficient resources",
+			err:      runFailure("failed to create cluster", "write /var/lib/docker/tmp/x: no space left on device"),
+			category: errorCategoryInsufficientResources,
+		},
+		{
+			name: "runtime unreachable",
+			err: fmt.Errorf("wrapped: %w", runFailure("failed to list nodes",
+				"Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?")),
+			category:  errorCategoryRuntimeUnreachable,
+			permanent: true,
+		},
+		{
+			name:     "delete timeout",
+			err:      fmt.Errorf("%w after 5m0s", errDeleteTimeout),
+			category: errorCategoryTimeout,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("something else"),
+			category: errorCategoryUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classified := classifyError(tt.err)
+
+			assert.Equal(t, tt.category, classified.Category)
+			assert.Equal(t, tt.permanent, classified.Permanent)
+
+			if tt.category != errorCategoryUnknown {
+				assert.NotEmpty(t, classified.Summary)
+				assert.NotEmpty(t, classified.Remediation)
+			}
+		})
+	}
+}
+
+func TestLogExcerpt(t *testing.T) {
+	var output strings.Builder
+
+	for i := range 40 {
+		fmt.Fprintf(&output, "line %d\n", i)
+	}
+
+	lines := strings.Split(logExcerpt(output.String(), nil), "\n")
+	assert.Len(t, lines, excerptLines)
+	assert.Equal(t, "line 39", lines[len(lines)-1])
+
+	lines = strings.Split(logExcerpt(output.String(), []string{"line 20"}), "\n")
+	assert.Len(t, lines, excerptLines)
+	assert.Equal(t, "line 17", lines[0])
+
+	assert.Equal(t,
+		"[wait-control-plane] Waiting for the kubelet to boot up the control plane as static Pods",
+		strings.Split(logExcerpt(kubeadmInitOutput, []string{"wait-control-plane", "kubelet-check"}), "\n")[excerptContext],
+	)
+	assert.Empty(t, logExcerpt("", nil))
+}
+
+func TestClassifiedErrorDiagnostic(t *testing.T) {
+	classified := classifyError(runFailure("failed to create cluster", "no space left on device"))
+
+	assert.Equal(t, "Error creating Kind cluster: Insufficient host resources",
+		classified.diagnosticSummary("Error creating Kind cluster"))
+	assert.Equal(t,
+		"Could not create cluster dev after 3 attempts\n\nkind output:\nno space left on device\n\n"+classified.Remediation,
+		classified.detail("Could not create cluster dev after 3 attempts"),
+	)
+
+	unknown := classifyError(errors.New("something else"))
+
+	assert.Equal(t, "Error deleting Kind cluster", unknown.diagnosticSummary("Error deleting Kind cluster"))
+	assert.Equal(t, "Could not delete cluster dev", unknown.detail("Could not delete cluster dev"))
+}
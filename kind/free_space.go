@@ -0,0 +1,36 @@
+//go:build linux || darwin || freebsd
+
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// freeDiskSpace returns the space available to unprivileged users on the file system of path.
+func freeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat the file system of %s: %w", path, err)
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:unconvert // field types differ between platforms
+}
@@ -0,0 +1,148 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kubeadmClusterConfigurationKind is the kubeadm ClusterConfiguration kind targeted by patches.
+	kubeadmClusterConfigurationKind = "ClusterConfiguration"
+	// kubeadmInitConfigurationKind is the kubeadm InitConfiguration kind targeted by patches.
+	kubeadmInitConfigurationKind = "InitConfiguration"
+	// kubeadmJoinConfigurationKind is the kubeadm JoinConfiguration kind targeted by patches.
+	kubeadmJoinConfigurationKind = "JoinConfiguration"
+	// yamlDocumentSeparator separates documents in a multi-document YAML patch.
+	yamlDocumentSeparator = "---\n"
+	// defaultAPIServerAddress is the address kind binds the API server to on the host.
+	defaultAPIServerAddress = "127.0.0.1"
+	// defaultAPIServerAddressIPv6 is the address kind binds the API server to in IPv6 clusters.
+	defaultAPIServerAddressIPv6 = "::1"
+	// remoteAPIServerAddress is the address the API server binds to when the runtime host is remote.
+	remoteAPIServerAddress = "0.0.0.0"
+	// remoteAPIServerAddressIPv6 is the remote API server bind address in IPv6 clusters.
+	remoteAPIServerAddressIPv6 = "::"
+)
+
+// apiServerCertSANs returns the API server certificate SANs kind configures by default,
+// followed by the extra SANs, without duplicates or empty entries.
+// Kind patches replace lists wholesale, so the defaults must be carried along.
+func apiServerCertSANs(cfg *v1alpha4.Cluster, extra ...string) []string {
+	address := cfg.Networking.APIServerAddress
+	if address == "" {
+		address = defaultAPIServerAddress
+		if cfg.Networking.IPFamily == v1alpha4.IPv6Family {
+			address = defaultAPIServerAddressIPv6
+		}
+	}
+
+	sans := []string{"localhost", address}
+
+	for _, san := range extra {
+		if san != "" && !slices.Contains(sans, san) {
+			sans = append(sans, san)
+		}
+	}
+
+	return sans
+}
+
+// certSANsPatch renders a kubeadm ClusterConfiguration patch setting the API server certificate SANs.
+// The patch omits apiVersion so kind applies it to whichever kubeadm API version it generates.
+func certSANsPatch(sans []string) (string, error) {
+	patch := map[string]any{
+		"kind": kubeadmClusterConfigurationKind,
+		"apiServer": map[string]any{
+			"certSANs": sans,
+		},
+	}
+
+	data, err := yaml.Marshal(patch)
+	if err != nil {
+		return "", fmt.Errorf("failed to render certSANs patch: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// clusterConfigurationPatch renders a kubeadm ClusterConfiguration patch carrying the extra
+// arguments of the control plane components. Kind generates v1beta2/v1beta3 kubeadm configs,
+// where extraArgs are maps, and kubeadm converts them to v1beta4 on newer node images.
+// Returns an empty string when no arguments are set.
+func clusterConfigurationPatch(
+	apiServerArgs, controllerManagerArgs, schedulerArgs map[string]string,
+) (string, error) {
+	patch := map[string]any{
+		"kind": kubeadmClusterConfigurationKind,
+	}
+
+	components := map[string]map[string]string{
+		"apiServer":         apiServerArgs,
+		"controllerManager": controllerManagerArgs,
+		"scheduler":         schedulerArgs,
+	}
+
+	for component, args := range components {
+		if len(args) > 0 {
+			patch[component] = map[string]any{"extraArgs": args}
+		}
+	}
+
+	if len(patch) == 1 {
+		return "", nil
+	}
+
+	data, err := yaml.Marshal(patch)
+	if err != nil {
+		return "", fmt.Errorf("failed to render ClusterConfiguration patch: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// kubeletExtraArgsPatch renders kubeadm InitConfiguration and JoinConfiguration patches
+// carrying kubelet extra arguments, so they apply whether the node initializes or joins the cluster.
+// Returns an empty string when no arguments are set.
+func kubeletExtraArgsPatch(args map[string]string) (string, error) {
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	documents := make([]string, 0, 2)
+
+	for _, kind := range []string{kubeadmInitConfigurationKind, kubeadmJoinConfigurationKind} {
+		data, err := yaml.Marshal(map[string]any{
+			"kind": kind,
+			"nodeRegistration": map[string]any{
+				"kubeletExtraArgs": args,
+			},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to render %s patch: %w", kind, err)
+		}
+
+		documents = append(documents, string(data))
+	}
+
+	return strings.Join(documents, yamlDocumentSeparator), nil
+}
@@ -0,0 +1,128 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
+)
+
+func TestAPIServerCertSANs(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *v1alpha4.Cluster
+		extra    []string
+		expected []string
+	}{
+		{
+			name:     "defaults to loopback",
+			config:   &v1alpha4.Cluster{},
+			expected: []string{"localhost", defaultAPIServerAddress},
+		},
+		{
+			name: "IPv6 defaults to IPv6 loopback",
+			config: &v1alpha4.Cluster{
+				Networking: v1alpha4.Networking{IPFamily: v1alpha4.IPv6Family},
+			},
+			expected: []string{"localhost", defaultAPIServerAddressIPv6},
+		},
+		{
+			name: "keeps configured address and deduplicates extras",
+			config: &v1alpha4.Cluster{
+				Networking: v1alpha4.Networking{APIServerAddress: testListenAddress},
+			},
+			extra:    []string{"docker", "", "localhost", "docker"},
+			expected: []string{"localhost", testListenAddress, "docker"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, apiServerCertSANs(tt.config, tt.extra...))
+		})
+	}
+}
+
+func TestCertSANsPatch(t *testing.T) {
+	patch, err := certSANsPatch([]string{"localhost", "docker"})
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(patch), &decoded))
+
+	assert.Equal(t, kubeadmClusterConfigurationKind, decoded["kind"])
+	assert.NotContains(t, decoded, "apiVersion", "patch should match any kubeadm API version")
+	assert.Equal(
+		t,
+		map[string]any{"certSANs": []any{"localhost", "docker"}},
+		decoded["apiServer"],
+	)
+}
+
+func TestClusterConfigurationPatch(t *testing.T) {
+	empty, err := clusterConfigurationPatch(nil, map[string]string{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty, "no arguments should render no patch")
+
+	patch, err := clusterConfigurationPatch(
+		map[string]string{"enable-admission-plugins": "NodeRestriction"},
+		nil,
+		map[string]string{"v": "4"},
+	)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(patch), &decoded))
+
+	assert.Equal(t, kubeadmClusterConfigurationKind, decoded["kind"])
+	assert.Equal(
+		t,
+		map[string]any{"extraArgs": map[string]any{"enable-admission-plugins": "NodeRestriction"}},
+		decoded["apiServer"],
+	)
+	assert.Equal(t, map[string]any{"extraArgs": map[string]any{"v": "4"}}, decoded["scheduler"])
+	assert.NotContains(t, decoded, "controllerManager", "components without arguments are omitted")
+}
+
+func TestKubeletExtraArgsPatch(t *testing.T) {
+	empty, err := kubeletExtraArgsPatch(nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty, "no arguments should render no patch")
+
+	patch, err := kubeletExtraArgsPatch(map[string]string{"max-pods": "50"})
+	require.NoError(t, err)
+
+	documents := strings.Split(patch, yamlDocumentSeparator)
+	require.Len(t, documents, 2, "patch should target init and join configurations")
+
+	for i, kind := range []string{kubeadmInitConfigurationKind, kubeadmJoinConfigurationKind} {
+		var decoded map[string]any
+		require.NoError(t, yaml.Unmarshal([]byte(documents[i]), &decoded))
+
+		assert.Equal(t, kind, decoded["kind"])
+		assert.Equal(
+			t,
+			map[string]any{"kubeletExtraArgs": map[string]any{"max-pods": "50"}},
+			decoded["nodeRegistration"],
+		)
+	}
+}
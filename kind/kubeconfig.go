@@ -0,0 +1,197 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ErrInvalidEndpointOverride is returned when an API server endpoint override cannot be parsed.
+//
+//nolint:grouper // false positive
+var ErrInvalidEndpointOverride = errors.New("invalid api server endpoint override")
+
+// overrideServerURL rewrites the API server URL using the override value.
+// The override can be a bare host ("docker"), a host with port ("docker:6443")
+// or a full URL ("https://docker:6443"). When no port is given the original port is kept.
+func overrideServerURL(server, override string) (string, error) {
+	if override == "" {
+		return server, nil
+	}
+
+	original, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse server URL %q: %w", server, err)
+	}
+
+	raw := override
+	if !strings.Contains(raw, "://") {
+		raw = original.Scheme + "://" + raw
+	}
+
+	target, err := url.Parse(raw)
+	if err != nil || target.Hostname() == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEndpointOverride, override)
+	}
+
+	port := target.Port()
+	if port == "" {
+		port = original.Port()
+	}
+
+	result := url.URL{Scheme: target.Scheme, Host: target.Hostname(), Path: target.Path}
+	if port != "" {
+		result.Host = net.JoinHostPort(target.Hostname(), port)
+	}
+
+	return result.String(), nil
+}
+
+// overrideHost returns the host name of an API server endpoint override,
+// suitable for inclusion in the API server certificate SANs.
+func overrideHost(override string) string {
+	if override == "" {
+		return ""
+	}
+
+	raw := override
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	target, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+
+	return target.Hostname()
+}
+
+// rewriteKubeconfigServer rewrites the server URL of every cluster in the kubeconfig
+// using the override value, returning the serialized kubeconfig.
+func rewriteKubeconfigServer(kubeconfig, override string) (string, error) {
+	if override == "" {
+		return kubeconfig, nil
+	}
+
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	for _, clusterConfig := range config.Clusters {
+		server, serverErr := overrideServerURL(clusterConfig.Server, override)
+		if serverErr != nil {
+			return "", serverErr
+		}
+
+		clusterConfig.Server = server
+	}
+
+	result, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize kubeconfig: %w", err)
+	}
+
+	return string(result), nil
+}
+
+// rewriteKubeconfigFileServer rewrites the server URL of the named cluster entry
+// in the kubeconfig file at configPath using the override value.
+func rewriteKubeconfigFileServer(configPath, clusterName, override string) error {
+	if override == "" {
+		return nil
+	}
+
+	config, err := clientcmd.LoadFromFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig %s: %w", configPath, err)
+	}
+
+	clusterConfig, exists := config.Clusters[clusterName]
+	if !exists {
+		return nil
+	}
+
+	server, err := overrideServerURL(clusterConfig.Server, override)
+	if err != nil {
+		return err
+	}
+
+	if server == clusterConfig.Server {
+		return nil
+	}
+
+	clusterConfig.Server = server
+
+	err = clientcmd.WriteToFile(*config, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", configPath, err)
+	}
+
+	return nil
+}
+
+// removeKubeconfigContext removes the cluster, user and context named contextName
+// from the kubeconfig at configPath. Failures are logged, cleanup is best effort.
+func removeKubeconfigContext(ctx context.Context, configPath, configType, contextName string) {
+	config, loadErr := clientcmd.LoadFromFile(configPath)
+	if loadErr != nil {
+		tflog.Warn(
+			ctx,
+			fmt.Sprintf(
+				"Unable to load %s kubeconfig for context cleanup: %v",
+				configType,
+				loadErr,
+			),
+		)
+
+		return
+	}
+
+	if _, exists := config.Contexts[contextName]; !exists {
+		return
+	}
+
+	delete(config.Contexts, contextName)
+	delete(config.AuthInfos, contextName)
+	delete(config.Clusters, contextName)
+
+	if config.CurrentContext == contextName {
+		config.CurrentContext = ""
+	}
+
+	writeErr := clientcmd.WriteToFile(*config, configPath)
+	if writeErr != nil {
+		tflog.Warn(
+			ctx,
+			fmt.Sprintf(
+				"Unable to write %s kubeconfig to remove context: %v",
+				configType,
+				writeErr,
+			),
+		)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// testServerURL is the host-loopback APIServer URL kind writes to kubeconfig.
+const testServerURL = "https://127.0.0.1:41234"
+
+// newTestKubeconfig returns a minimal kubeconfig for the named cluster.
+func newTestKubeconfig(t *testing.T, clusterName, server string) string {
+	t.Helper()
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[clusterName] = &clientcmdapi.Cluster{Server: server}
+	config.AuthInfos[clusterName] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts[clusterName] = &clientcmdapi.Context{Cluster: clusterName, AuthInfo: clusterName}
+	config.CurrentContext = clusterName
+
+	data, err := clientcmd.Write(*config)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestOverrideServerURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "empty override keeps server",
+			override: "",
+			expected: testServerURL,
+		},
+		{
+			name:     "bare host keeps scheme and port",
+			override: "docker",
+			expected: "https://docker:41234",
+		},
+		{
+			name:     "host with port replaces port",
+			override: "docker:6443",
+			expected: "https://docker:6443",
+		},
+		{
+			name:     "full URL replaces everything",
+			override: "https://kind.example.com:8443",
+			expected: "https://kind.example.com:8443",
+		},
+		{
+			name:     "IPv6 host is bracketed",
+			override: "[fd00::1]",
+			expected: "https://[fd00::1]:41234",
+		},
+		{
+			name:     "missing host is rejected",
+			override: "https://:6443",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := overrideServerURL(testServerURL, tt.override)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidEndpointOverride)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestOverrideHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		expected string
+	}{
+		{name: "empty", override: "", expected: ""},
+		{name: "bare host", override: "docker", expected: "docker"},
+		{name: "host with port", override: "docker:6443", expected: "docker"},
+		{name: "full URL", override: "https://kind.example.com:8443", expected: "kind.example.com"},
+		{name: "IPv6", override: "[fd00::1]:6443", expected: "fd00::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, overrideHost(tt.override))
+		})
+	}
+}
+
+func TestRewriteKubeconfigServer(t *testing.T) {
+	kubeconfig := newTestKubeconfig(t, "kind-test", testServerURL)
+
+	unchanged, err := rewriteKubeconfigServer(kubeconfig, "")
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfig, unchanged, "empty override should not touch the kubeconfig")
+
+	rewritten, err := rewriteKubeconfigServer(kubeconfig, "docker")
+	require.NoError(t, err)
+
+	config, err := clientcmd.Load([]byte(rewritten))
+	require.NoError(t, err)
+	assert.Equal(t, "https://docker:41234", config.Clusters["kind-test"].Server)
+	assert.Equal(t, "token", config.AuthInfos["kind-test"].Token, "credentials should be preserved")
+}
+
+func TestRewriteKubeconfigFileServer(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	config := clientcmdapi.NewConfig()
+	config.Clusters["kind-test"] = &clientcmdapi.Cluster{Server: testServerURL}
+	config.Clusters["other"] = &clientcmdapi.Cluster{Server: testServerURL}
+	require.NoError(t, clientcmd.WriteToFile(*config, configPath))
+
+	require.NoError(t, rewriteKubeconfigFileServer(configPath, "kind-test", "docker:6443"))
+
+	loaded, err := clientcmd.LoadFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "https://docker:6443", loaded.Clusters["kind-test"].Server)
+	assert.Equal(t, testServerURL, loaded.Clusters["other"].Server, "other clusters should be untouched")
+
+	require.NoError(
+		t,
+		rewriteKubeconfigFileServer(configPath, "kind-missing", "docker"),
+		"missing cluster entries should be ignored",
+	)
+}
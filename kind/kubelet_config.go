@@ -0,0 +1,311 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kubeletConfigurationKind is the KubeletConfiguration kind targeted by patches.
+	kubeletConfigurationKind = "KubeletConfiguration"
+	// kubeletConfigurationAPIVersion is the only KubeletConfiguration API version kind generates.
+	kubeletConfigurationAPIVersion = "kubelet.config.k8s.io/v1beta1"
+	// maxPercent is the upper bound of percentage settings.
+	maxPercent = 100
+)
+
+// ErrInvalidKubeletConfig is returned when a kubelet_config block contains an invalid setting.
+//
+//nolint:grouper // false positive
+var ErrInvalidKubeletConfig = errors.New("invalid kubelet_config")
+
+var (
+	// evictionSignals lists the eviction signals supported by the kubelet.
+	evictionSignals = []string{
+		"memory.available",
+		"nodefs.available",
+		"nodefs.inodesFree",
+		"imagefs.available",
+		"imagefs.inodesFree",
+		"containerfs.available",
+		"containerfs.inodesFree",
+		"pid.available",
+	}
+
+	// kindEvictionHard holds the hard eviction thresholds kind sets in its KubeletConfiguration,
+	// which disable disk pressure eviction on the shared host filesystem.
+	kindEvictionHard = map[string]string{
+		"nodefs.available":  "0%",
+		"nodefs.inodesFree": "0%",
+		"imagefs.available": "0%",
+	}
+
+	// reservedResources lists the resources that can be reserved for system and kube daemons.
+	reservedResources = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+
+	// sysctlPattern matches sysctl names, optionally ending in a wildcard.
+	sysctlPattern = regexp.MustCompile(`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-_a-z0-9]*[a-z0-9])?)*(\.?\*)?$`)
+)
+
+// kubeletConfigSettings holds the structured kubelet_config settings.
+type kubeletConfigSettings struct {
+	EvictionHard                map[string]string
+	SystemReserved              map[string]string
+	KubeReserved                map[string]string
+	AllowedUnsafeSysctls        []string
+	MaxPods                     int
+	ImageGCHighThresholdPercent int
+	ImageGCLowThresholdPercent  int
+}
+
+// flattenKubeletConfig converts a map representation of a kubelet_config block to kubeletConfigSettings.
+// Returns nil if the block is not set.
+func flattenKubeletConfig(kubeletConfig map[string]any) *kubeletConfigSettings {
+	if kubeletConfig == nil {
+		return nil
+	}
+
+	return &kubeletConfigSettings{
+		MaxPods:                     getInt(kubeletConfig, "max_pods"),
+		EvictionHard:                getStringMap(kubeletConfig, "eviction_hard"),
+		SystemReserved:              getStringMap(kubeletConfig, "system_reserved"),
+		KubeReserved:                getStringMap(kubeletConfig, "kube_reserved"),
+		ImageGCHighThresholdPercent: getInt(kubeletConfig, "image_gc_high_threshold_percent"),
+		ImageGCLowThresholdPercent:  getInt(kubeletConfig, "image_gc_low_threshold_percent"),
+		AllowedUnsafeSysctls:        getStringSlice(kubeletConfig, "allowed_unsafe_sysctls"),
+	}
+}
+
+// validate checks the settings against the constraints enforced by the kubelet.
+func (s *kubeletConfigSettings) validate() error {
+	if s == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if s.MaxPods < 0 {
+		errs = append(errs, fmt.Errorf("max_pods must be positive, got %d", s.MaxPods))
+	}
+
+	for name, percent := range map[string]int{
+		"image_gc_high_threshold_percent": s.ImageGCHighThresholdPercent,
+		"image_gc_low_threshold_percent":  s.ImageGCLowThresholdPercent,
+	} {
+		if percent < 0 || percent > maxPercent {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and %d, got %d", name, maxPercent, percent))
+		}
+	}
+
+	if s.ImageGCHighThresholdPercent != 0 && s.ImageGCLowThresholdPercent != 0 &&
+		s.ImageGCLowThresholdPercent >= s.ImageGCHighThresholdPercent {
+		errs = append(errs, fmt.Errorf(
+			"image_gc_low_threshold_percent (%d) must be less than image_gc_high_threshold_percent (%d)",
+			s.ImageGCLowThresholdPercent,
+			s.ImageGCHighThresholdPercent,
+		))
+	}
+
+	for _, signal := range slices.Sorted(maps.Keys(s.EvictionHard)) {
+		errs = append(errs, validateEvictionThreshold(signal, s.EvictionHard[signal]))
+	}
+
+	for name, reserved := range map[string]map[string]string{
+		"system_reserved": s.SystemReserved,
+		"kube_reserved":   s.KubeReserved,
+	} {
+		for _, resourceName := range slices.Sorted(maps.Keys(reserved)) {
+			errs = append(errs, validateReservedResource(name, resourceName, reserved[resourceName]))
+		}
+	}
+
+	for _, sysctl := range s.AllowedUnsafeSysctls {
+		if !sysctlPattern.MatchString(sysctl) {
+			errs = append(errs, fmt.Errorf("allowed_unsafe_sysctls entry %q is not a valid sysctl name", sysctl))
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidKubeletConfig, err)
+	}
+
+	return nil
+}
+
+// validateEvictionThreshold checks an eviction_hard entry: a known signal with a quantity or percentage.
+func validateEvictionThreshold(signal, threshold string) error {
+	if !slices.Contains(evictionSignals, signal) {
+		return fmt.Errorf(
+			"eviction_hard signal %q is not supported (must be one of %s)",
+			signal,
+			strings.Join(evictionSignals, ", "),
+		)
+	}
+
+	if percent, isPercent := strings.CutSuffix(threshold, "%"); isPercent {
+		value, err := strconv.ParseFloat(percent, 64)
+		if err != nil || value < 0 || value > maxPercent {
+			return fmt.Errorf("eviction_hard threshold %q for %s is not a valid percentage", threshold, signal)
+		}
+
+		return nil
+	}
+
+	_, err := resource.ParseQuantity(threshold)
+	if err != nil {
+		return fmt.Errorf("eviction_hard threshold %q for %s is not a valid quantity", threshold, signal)
+	}
+
+	return nil
+}
+
+// validateReservedResource checks a system_reserved or kube_reserved entry.
+func validateReservedResource(field, resourceName, quantity string) error {
+	if !slices.Contains(reservedResources, resourceName) {
+		return fmt.Errorf(
+			"%s resource %q is not supported (must be one of %s)",
+			field,
+			resourceName,
+			strings.Join(reservedResources, ", "),
+		)
+	}
+
+	_, err := resource.ParseQuantity(quantity)
+	if err != nil {
+		return fmt.Errorf("%s quantity %q for %s is not valid", field, quantity, resourceName)
+	}
+
+	return nil
+}
+
+// configurationPatch renders the settings as a KubeletConfiguration kubeadm patch.
+// Maps are merged with the kind defaults, so e.g. kind's disk eviction thresholds
+// are kept unless they are overridden. Returns an empty string when nothing is set.
+func (s *kubeletConfigSettings) configurationPatch() (string, error) {
+	if s == nil {
+		return "", nil
+	}
+
+	fields := map[string]any{}
+
+	if s.MaxPods != 0 {
+		fields["maxPods"] = s.MaxPods
+	}
+
+	if len(s.EvictionHard) > 0 {
+		fields["evictionHard"] = s.EvictionHard
+	}
+
+	if len(s.SystemReserved) > 0 {
+		fields["systemReserved"] = s.SystemReserved
+	}
+
+	if len(s.KubeReserved) > 0 {
+		fields["kubeReserved"] = s.KubeReserved
+	}
+
+	if s.ImageGCHighThresholdPercent != 0 {
+		fields["imageGCHighThresholdPercent"] = s.ImageGCHighThresholdPercent
+	}
+
+	if s.ImageGCLowThresholdPercent != 0 {
+		fields["imageGCLowThresholdPercent"] = s.ImageGCLowThresholdPercent
+	}
+
+	if len(s.AllowedUnsafeSysctls) > 0 {
+		fields["allowedUnsafeSysctls"] = s.AllowedUnsafeSysctls
+	}
+
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	fields["kind"] = kubeletConfigurationKind
+	fields["apiVersion"] = kubeletConfigurationAPIVersion
+
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to render KubeletConfiguration patch: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// extraArgs renders the settings as kubelet command line flags. Joining nodes take their
+// KubeletConfiguration from the cluster, so node-level overrides are passed as flags,
+// which take precedence over the configuration file. The --eviction-hard flag replaces the
+// whole evictionHard map, so kind's defaults are merged into it.
+func (s *kubeletConfigSettings) extraArgs() map[string]string {
+	args := make(map[string]string)
+
+	if s == nil {
+		return args
+	}
+
+	if s.MaxPods != 0 {
+		args["max-pods"] = strconv.Itoa(s.MaxPods)
+	}
+
+	if len(s.EvictionHard) > 0 {
+		evictionHard := maps.Clone(kindEvictionHard)
+		maps.Copy(evictionHard, s.EvictionHard)
+		args["eviction-hard"] = joinKeyValues(evictionHard, "<")
+	}
+
+	if len(s.SystemReserved) > 0 {
+		args["system-reserved"] = joinKeyValues(s.SystemReserved, "=")
+	}
+
+	if len(s.KubeReserved) > 0 {
+		args["kube-reserved"] = joinKeyValues(s.KubeReserved, "=")
+	}
+
+	if s.ImageGCHighThresholdPercent != 0 {
+		args["image-gc-high-threshold"] = strconv.Itoa(s.ImageGCHighThresholdPercent)
+	}
+
+	if s.ImageGCLowThresholdPercent != 0 {
+		args["image-gc-low-threshold"] = strconv.Itoa(s.ImageGCLowThresholdPercent)
+	}
+
+	if len(s.AllowedUnsafeSysctls) > 0 {
+		args["allowed-unsafe-sysctls"] = strings.Join(s.AllowedUnsafeSysctls, ",")
+	}
+
+	return args
+}
+
+// joinKeyValues renders a map as a sorted, comma separated list of key-separator-value pairs.
+func joinKeyValues(values map[string]string, separator string) string {
+	pairs := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		pairs = append(pairs, key+separator+values[key])
+	}
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,181 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestFlattenKubeletConfig(t *testing.T) {
+	assert.Nil(t, flattenKubeletConfig(nil), "unset block should flatten to nil")
+
+	settings := flattenKubeletConfig(map[string]any{
+		"max_pods":                        50,
+		"eviction_hard":                   map[string]any{"memory.available": "100Mi"},
+		"image_gc_high_threshold_percent": 85,
+		"allowed_unsafe_sysctls":          []any{"net.core.*"},
+	})
+
+	require.NotNil(t, settings)
+	assert.Equal(t, 50, settings.MaxPods)
+	assert.Equal(t, map[string]string{"memory.available": "100Mi"}, settings.EvictionHard)
+	assert.Equal(t, 85, settings.ImageGCHighThresholdPercent)
+	assert.Equal(t, []string{"net.core.*"}, settings.AllowedUnsafeSysctls)
+}
+
+func TestKubeletConfigSettingsValidate(t *testing.T) {
+	tests := []struct {
+		settings    *kubeletConfigSettings
+		name        string
+		errContains string
+	}{
+		{
+			name:     "nil settings are valid",
+			settings: nil,
+		},
+		{
+			name: "valid settings",
+			settings: &kubeletConfigSettings{
+				MaxPods:                     110,
+				EvictionHard:                map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+				SystemReserved:              map[string]string{"cpu": "100m", "memory": "256Mi"},
+				ImageGCHighThresholdPercent: 85,
+				ImageGCLowThresholdPercent:  80,
+				AllowedUnsafeSysctls:        []string{"net.core.somaxconn", "kernel.msg*", "net.ipv4.*"},
+			},
+		},
+		{
+			name:        "negative max pods",
+			settings:    &kubeletConfigSettings{MaxPods: -1},
+			errContains: "max_pods",
+		},
+		{
+			name:        "percentage out of range",
+			settings:    &kubeletConfigSettings{ImageGCHighThresholdPercent: 101},
+			errContains: "image_gc_high_threshold_percent must be between",
+		},
+		{
+			name: "low threshold above high threshold",
+			settings: &kubeletConfigSettings{
+				ImageGCHighThresholdPercent: 70,
+				ImageGCLowThresholdPercent:  80,
+			},
+			errContains: "must be less than",
+		},
+		{
+			name:        "unknown eviction signal",
+			settings:    &kubeletConfigSettings{EvictionHard: map[string]string{"cpu.available": "1"}},
+			errContains: "signal \"cpu.available\" is not supported",
+		},
+		{
+			name:        "invalid eviction percentage",
+			settings:    &kubeletConfigSettings{EvictionHard: map[string]string{"nodefs.available": "110%"}},
+			errContains: "not a valid percentage",
+		},
+		{
+			name:        "invalid eviction quantity",
+			settings:    &kubeletConfigSettings{EvictionHard: map[string]string{"memory.available": "lots"}},
+			errContains: "not a valid quantity",
+		},
+		{
+			name:        "unknown reserved resource",
+			settings:    &kubeletConfigSettings{KubeReserved: map[string]string{"gpu": "1"}},
+			errContains: "kube_reserved resource \"gpu\"",
+		},
+		{
+			name:        "invalid reserved quantity",
+			settings:    &kubeletConfigSettings{SystemReserved: map[string]string{"memory": "1 GB"}},
+			errContains: "system_reserved quantity",
+		},
+		{
+			name:        "invalid sysctl",
+			settings:    &kubeletConfigSettings{AllowedUnsafeSysctls: []string{"net core"}},
+			errContains: "not a valid sysctl name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidKubeletConfig)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestKubeletConfigSettingsConfigurationPatch(t *testing.T) {
+	var unset *kubeletConfigSettings
+
+	empty, err := unset.configurationPatch()
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	empty, err = (&kubeletConfigSettings{}).configurationPatch()
+	require.NoError(t, err)
+	assert.Empty(t, empty, "settings without values should render no patch")
+
+	patch, err := (&kubeletConfigSettings{
+		MaxPods:                     50,
+		EvictionHard:                map[string]string{"memory.available": "100Mi"},
+		ImageGCHighThresholdPercent: 85,
+		AllowedUnsafeSysctls:        []string{"net.core.*"},
+	}).configurationPatch()
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(patch), &decoded))
+
+	assert.Equal(t, kubeletConfigurationKind, decoded["kind"])
+	assert.Equal(t, kubeletConfigurationAPIVersion, decoded["apiVersion"])
+	assert.InDelta(t, 50, decoded["maxPods"], 0)
+	assert.InDelta(t, 85, decoded["imageGCHighThresholdPercent"], 0)
+	assert.Equal(t, map[string]any{"memory.available": "100Mi"}, decoded["evictionHard"])
+	assert.Equal(t, []any{"net.core.*"}, decoded["allowedUnsafeSysctls"])
+	assert.NotContains(t, decoded, "systemReserved", "unset fields should be omitted")
+}
+
+func TestKubeletConfigSettingsExtraArgs(t *testing.T) {
+	var unset *kubeletConfigSettings
+
+	assert.Empty(t, unset.extraArgs())
+
+	args := (&kubeletConfigSettings{
+		MaxPods:                    50,
+		EvictionHard:               map[string]string{"nodefs.available": "10%", "memory.available": "100Mi"},
+		SystemReserved:             map[string]string{"memory": "256Mi", "cpu": "100m"},
+		ImageGCLowThresholdPercent: 60,
+		AllowedUnsafeSysctls:       []string{"net.core.*", "kernel.msg*"},
+	}).extraArgs()
+
+	assert.Equal(t, map[string]string{
+		"max-pods":               "50",
+		"eviction-hard":          "imagefs.available<0%,memory.available<100Mi,nodefs.available<10%,nodefs.inodesFree<0%",
+		"system-reserved":        "cpu=100m,memory=256Mi",
+		"image-gc-low-threshold": "60",
+		"allowed-unsafe-sysctls": "net.core.*,kernel.msg*",
+	}, args)
+}
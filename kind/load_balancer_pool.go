@@ -0,0 +1,320 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const (
+	// loadBalancerPoolPath records the address pool of a cluster inside its control-plane nodes,
+	// so that clusters created later on the same network do not reuse it.
+	loadBalancerPoolPath = "/kind/terraform-load-balancer-address-pool"
+	// maxLoadBalancerPoolSize bounds load_balancer_pool_size.
+	maxLoadBalancerPoolSize = 4096
+	// kindRoleLabel is the container label holding the role of a kind node.
+	kindRoleLabel = "io.x-k8s.kind.role"
+)
+
+var (
+	// ErrAddressPoolExhausted is returned when no free range of the requested size is left in a subnet.
+	ErrAddressPoolExhausted = errors.New("no free address range left")
+	// ErrInvalidAddressRange is returned when an address range is not in the start-end notation.
+	ErrInvalidAddressRange = errors.New("invalid address range")
+)
+
+// loadBalancerPoolGuard serializes allocations, so concurrent creates in one plugin process
+// never hand out the same range.
+//
+//nolint:gochecknoglobals // process-wide allocation lock
+var loadBalancerPoolGuard sync.Mutex
+
+// addressRange is an inclusive range of IP addresses.
+type addressRange struct {
+	start netip.Addr
+	end   netip.Addr
+}
+
+// String returns the range in the start-end notation MetalLB accepts.
+func (r addressRange) String() string {
+	return r.start.String() + "-" + r.end.String()
+}
+
+// contains reports whether the address is in the range.
+func (r addressRange) contains(addr netip.Addr) bool {
+	return r.start.Compare(addr) <= 0 && addr.Compare(r.end) <= 0
+}
+
+// overlaps reports whether both ranges share an address.
+func (r addressRange) overlaps(other addressRange) bool {
+	return r.start.Compare(other.end) <= 0 && other.start.Compare(r.end) <= 0
+}
+
+// parseAddressRange parses a range in the start-end notation.
+func parseAddressRange(value string) (addressRange, error) {
+	startValue, endValue, found := strings.Cut(strings.TrimSpace(value), "-")
+	if !found {
+		return addressRange{}, fmt.Errorf("%w: %q", ErrInvalidAddressRange, value)
+	}
+
+	start, err := netip.ParseAddr(startValue)
+	if err != nil {
+		return addressRange{}, fmt.Errorf("%w: %q: %w", ErrInvalidAddressRange, value, err)
+	}
+
+	end, err := netip.ParseAddr(endValue)
+	if err != nil {
+		return addressRange{}, fmt.Errorf("%w: %q: %w", ErrInvalidAddressRange, value, err)
+	}
+
+	return addressRange{start: start, end: end}, nil
+}
+
+// lastAddress returns the highest address of the prefix.
+func lastAddress(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Masked().Addr().AsSlice()
+
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+
+	addr, _ := netip.AddrFromSlice(bytes)
+
+	return addr
+}
+
+// allocateAddressRange carves a range of size addresses from the top of the prefix, below the
+// broadcast address, that holds none of the used addresses and overlaps none of the taken ranges.
+func allocateAddressRange(
+	prefix netip.Prefix,
+	size int,
+	used []netip.Addr,
+	taken []addressRange,
+) (addressRange, error) {
+	prefix = prefix.Masked()
+	end := lastAddress(prefix).Prev()
+
+	for {
+		start := end
+
+		for range size - 1 {
+			start = start.Prev()
+		}
+
+		// The network address is never handed out
+		if !start.IsValid() || !prefix.Contains(start) || start.Compare(prefix.Addr()) <= 0 {
+			return addressRange{}, fmt.Errorf("%w: %d addresses in %s", ErrAddressPoolExhausted, size, prefix)
+		}
+
+		candidate := addressRange{start: start, end: end}
+		lowest := netip.Addr{}
+
+		for _, addr := range used {
+			if candidate.contains(addr) && (!lowest.IsValid() || addr.Less(lowest)) {
+				lowest = addr
+			}
+		}
+
+		for _, other := range taken {
+			if candidate.overlaps(other) && (!lowest.IsValid() || other.start.Less(lowest)) {
+				lowest = other.start
+			}
+		}
+
+		if !lowest.IsValid() {
+			return candidate, nil
+		}
+
+		// Continue right below the lowest collision
+		end = lowest.Prev()
+		if !end.IsValid() || !prefix.Contains(end) {
+			return addressRange{}, fmt.Errorf("%w: %d addresses in %s", ErrAddressPoolExhausted, size, prefix)
+		}
+	}
+}
+
+// addressPoolFamilies returns whether the IPv4 and IPv6 pools are needed for the IP family.
+func addressPoolFamilies(ipFamily v1alpha4.ClusterIPFamily) (bool, bool) {
+	switch ipFamily {
+	case v1alpha4.IPv6Family:
+		return false, true
+	case v1alpha4.DualStackFamily:
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// networkAddresses returns the addresses of every container attached to the network.
+func (r *kindRuntime) networkAddresses(ctx context.Context, containers []string) ([]netip.Addr, error) {
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{
+		"inspect", "--format",
+		"{{range .NetworkSettings.Networks}}{{.IPAddress}} {{.GlobalIPv6Address}} {{end}}",
+	}, containers...)
+
+	output, err := r.containerCommand(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []netip.Addr
+
+	for _, field := range strings.Fields(output) {
+		addr, parseErr := netip.ParseAddr(field)
+		if parseErr == nil {
+			addresses = append(addresses, addr)
+		}
+	}
+
+	return addresses, nil
+}
+
+// recordedAddressPools returns the ranges recorded in the control-plane nodes on the network.
+// They are copied out of the containers, which also works while a cluster is stopped.
+func (r *kindRuntime) recordedAddressPools(ctx context.Context, network string) []addressRange {
+	output, err := r.containerCommand(ctx,
+		"ps", "--all",
+		"--filter", "network="+network,
+		"--filter", "label="+kindRoleLabel+"="+string(v1alpha4.ControlPlaneRole),
+		"--format", "{{.Names}}",
+	)
+	if err != nil {
+		return nil
+	}
+
+	var ranges []addressRange
+
+	for _, node := range strings.Fields(output) {
+		archive, cpErr := r.containerCommand(ctx, "cp", node+":"+loadBalancerPoolPath, "-")
+		if cpErr != nil {
+			// Clusters without a pool have no record
+			continue
+		}
+
+		ranges = append(ranges, parseAddressPoolRecord(archive)...)
+	}
+
+	return ranges
+}
+
+// parseAddressPoolRecord reads the ranges out of the tar archive of a pool record.
+func parseAddressPoolRecord(archive string) []addressRange {
+	reader := tar.NewReader(strings.NewReader(archive))
+
+	if _, err := reader.Next(); err != nil {
+		return nil
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil
+	}
+
+	var ranges []addressRange
+
+	for line := range strings.Lines(string(content)) {
+		poolRange, parseErr := parseAddressRange(line)
+		if parseErr == nil {
+			ranges = append(ranges, poolRange)
+		}
+	}
+
+	return ranges
+}
+
+// allocateAddressPool carves the load balancer address pool of a cluster from its node
+// network and records it in the control-plane nodes. It returns one range per IP family.
+func (r *kindRuntime) allocateAddressPool(
+	ctx context.Context,
+	clusterName, network string,
+	ipFamily v1alpha4.ClusterIPFamily,
+	size int,
+) ([]string, error) {
+	loadBalancerPoolGuard.Lock()
+	defer loadBalancerPoolGuard.Unlock()
+
+	info, err := r.inspectNetwork(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := r.networkAddresses(ctx, info.Containers)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := r.recordedAddressPools(ctx, network)
+	wantIPv4, wantIPv6 := addressPoolFamilies(ipFamily)
+
+	var pool []string
+
+	for _, family := range []struct {
+		name string
+		ipv6 bool
+		want bool
+	}{{"IPv4", false, wantIPv4}, {"IPv6", true, wantIPv6}} {
+		if !family.want {
+			continue
+		}
+
+		subnet := info.subnet(family.ipv6)
+
+		prefix, parseErr := netip.ParsePrefix(subnet.Subnet)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: network %s has no %s subnet", ErrInvalidNetwork, network, family.name)
+		}
+
+		familyUsed := slices.Clone(used)
+
+		if gateway, gatewayErr := netip.ParseAddr(subnet.Gateway); gatewayErr == nil {
+			familyUsed = append(familyUsed, gateway)
+		}
+
+		poolRange, allocErr := allocateAddressRange(prefix, size, familyUsed, taken)
+		if allocErr != nil {
+			return nil, fmt.Errorf("failed to allocate the load balancer address pool: %w", allocErr)
+		}
+
+		pool = append(pool, poolRange.String())
+	}
+
+	nodeList, err := r.selectClusterNodes(clusterName, string(v1alpha4.ControlPlaneRole), "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.writeNodeFiles(nodeList, map[string]string{loadBalancerPoolPath: strings.Join(pool, "\n") + "\n"})
+	if err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
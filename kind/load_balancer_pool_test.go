@@ -0,0 +1,207 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"archive/tar"
+	"context"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func testAddressPoolRecord(t *testing.T, content string) string {
+	t.Helper()
+
+	var archive strings.Builder
+
+	writer := tar.NewWriter(&archive)
+	require.NoError(t, writer.WriteHeader(&tar.Header{Name: "pool", Mode: 0o644, Size: int64(len(content))}))
+	_, err := writer.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return archive.String()
+}
+
+func TestLastAddress(t *testing.T) {
+	assert.Equal(t, "172.18.255.255", lastAddress(netip.MustParsePrefix("172.18.0.0/16")).String())
+	assert.Equal(t, "10.89.0.255", lastAddress(netip.MustParsePrefix("10.89.0.7/24")).String())
+	assert.Equal(t, "fc00:f853:ccd:e793:ffff:ffff:ffff:ffff",
+		lastAddress(netip.MustParsePrefix("fc00:f853:ccd:e793::/64")).String())
+}
+
+func TestParseAddressRange(t *testing.T) {
+	poolRange, err := parseAddressRange("172.18.255.240-172.18.255.254\n")
+	require.NoError(t, err)
+	assert.Equal(t, "172.18.255.240-172.18.255.254", poolRange.String())
+
+	for _, value := range []string{"172.18.255.240", "172.18.255.240-end", "start-172.18.255.254"} {
+		_, err = parseAddressRange(value)
+		require.ErrorIs(t, err, ErrInvalidAddressRange, value)
+	}
+}
+
+func TestAllocateAddressRange(t *testing.T) {
+	mustRange := func(value string) addressRange {
+		poolRange, err := parseAddressRange(value)
+		require.NoError(t, err)
+
+		return poolRange
+	}
+
+	tests := []struct {
+		name    string
+		prefix  string
+		want    string
+		used    []netip.Addr
+		taken   []addressRange
+		size    int
+		wantErr bool
+	}{
+		{
+			name:   "top of the subnet",
+			prefix: "172.18.0.0/16",
+			size:   15,
+			used:   []netip.Addr{netip.MustParseAddr("172.18.0.1"), netip.MustParseAddr("172.18.0.2")},
+			want:   "172.18.255.240-172.18.255.254",
+		},
+		{
+			name:   "below a node address",
+			prefix: "172.18.0.0/16",
+			size:   10,
+			used:   []netip.Addr{netip.MustParseAddr("172.18.255.250")},
+			want:   "172.18.255.240-172.18.255.249",
+		},
+		{
+			name:   "below pools of other clusters",
+			prefix: "172.18.0.0/16",
+			size:   16,
+			taken:  []addressRange{mustRange("172.18.255.240-172.18.255.254"), mustRange("172.18.255.220-172.18.255.239")},
+			want:   "172.18.255.204-172.18.255.219",
+		},
+		{
+			name:   "ipv6",
+			prefix: "fc00:f853:ccd:e793::/64",
+			size:   16,
+			want:   "fc00:f853:ccd:e793:ffff:ffff:ffff:ffef-fc00:f853:ccd:e793:ffff:ffff:ffff:fffe",
+		},
+		{
+			name:   "fills the subnet",
+			prefix: "10.89.0.0/28",
+			size:   8,
+			used:   []netip.Addr{netip.MustParseAddr("10.89.0.9")},
+			want:   "10.89.0.1-10.89.0.8",
+		},
+		{
+			name:    "too large",
+			prefix:  "10.89.0.0/28",
+			size:    16,
+			wantErr: true,
+		},
+		{
+			name:    "exhausted",
+			prefix:  "10.89.0.0/28",
+			size:    8,
+			used:    []netip.Addr{netip.MustParseAddr("10.89.0.1"), netip.MustParseAddr("10.89.0.9")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poolRange, err := allocateAddressRange(netip.MustParsePrefix(tt.prefix), tt.size, tt.used, tt.taken)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrAddressPoolExhausted)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, poolRange.String())
+		})
+	}
+}
+
+func TestAddressPoolFamilies(t *testing.T) {
+	for _, tt := range []struct {
+		ipFamily v1alpha4.ClusterIPFamily
+		ipv4     bool
+		ipv6     bool
+	}{
+		{ipFamily: "", ipv4: true},
+		{ipFamily: v1alpha4.IPv4Family, ipv4: true},
+		{ipFamily: v1alpha4.IPv6Family, ipv6: true},
+		{ipFamily: v1alpha4.DualStackFamily, ipv4: true, ipv6: true},
+	} {
+		ipv4, ipv6 := addressPoolFamilies(tt.ipFamily)
+		assert.Equal(t, tt.ipv4, ipv4, tt.ipFamily)
+		assert.Equal(t, tt.ipv6, ipv6, tt.ipFamily)
+	}
+}
+
+func TestParseAddressPoolRecord(t *testing.T) {
+	record := testAddressPoolRecord(t, "172.18.255.240-172.18.255.254\nfc00::1-fc00::f\ngarbage\n")
+
+	assert.Equal(t, []string{"172.18.255.240-172.18.255.254", "fc00::1-fc00::f"},
+		addressRangeStrings(parseAddressPoolRecord(record)))
+	assert.Empty(t, parseAddressPoolRecord("Error: no such file"))
+}
+
+func TestRecordedAddressPools(t *testing.T) {
+	runtime, calls := fakeRuntimeCLI(t, map[string]string{
+		"ps --all": "dev-control-plane\nstaging-control-plane\n",
+		"cp dev-control-plane:" + loadBalancerPoolPath: testAddressPoolRecord(t, "172.18.255.240-172.18.255.254\n"),
+	})
+
+	pools := runtime.recordedAddressPools(context.Background(), "kind")
+
+	assert.Equal(t, []string{"172.18.255.240-172.18.255.254"}, addressRangeStrings(pools))
+	assert.Contains(t, calls(), "cp staging-control-plane:"+loadBalancerPoolPath+" -")
+}
+
+func TestNetworkAddresses(t *testing.T) {
+	runtime, _ := fakeRuntimeCLI(t, map[string]string{
+		"inspect --format": "172.18.0.2 fc00:f853:ccd:e793::2 \n172.18.0.3  \n",
+	})
+
+	addresses, err := runtime.networkAddresses(context.Background(), []string{"dev-control-plane", "dev-worker"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []netip.Addr{
+		netip.MustParseAddr("172.18.0.2"),
+		netip.MustParseAddr("fc00:f853:ccd:e793::2"),
+		netip.MustParseAddr("172.18.0.3"),
+	}, addresses)
+
+	addresses, err = runtime.networkAddresses(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, addresses)
+}
+
+func addressRangeStrings(ranges []addressRange) []string {
+	values := make([]string, 0, len(ranges))
+	for _, poolRange := range ranges {
+		values = append(values, poolRange.String())
+	}
+
+	return values
+}
@@ -0,0 +1,420 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// manifestFieldManager is the server-side apply field manager of bootstrap manifests.
+	manifestFieldManager = "terraform-provider-kind"
+	// manifestDecodeBufferSize is the read-ahead used to tell YAML from JSON documents.
+	manifestDecodeBufferSize = 4096
+	// manifestMappingTimeout bounds the wait for freshly applied CRDs to be served.
+	manifestMappingTimeout = 30 * time.Second
+	// manifestMappingInterval is the delay between REST mapping attempts.
+	manifestMappingInterval = time.Second
+)
+
+// ErrInvalidManifest is returned when a bootstrap manifest cannot be read or decoded.
+//
+//nolint:grouper // false positive
+var ErrInvalidManifest = errors.New("invalid bootstrap manifest")
+
+// manifestObject is a decoded object together with the index of the manifest it came from.
+type manifestObject struct {
+	object *unstructured.Unstructured
+	source int
+}
+
+// manifestApplyError is the failure to apply a single object of a bootstrap manifest.
+type manifestApplyError struct {
+	err    error
+	object string
+	source int
+}
+
+// Error implements the error interface.
+func (e manifestApplyError) Error() string {
+	return fmt.Sprintf("%s: %v", e.object, e.err)
+}
+
+// Unwrap returns the underlying apply error.
+func (e manifestApplyError) Unwrap() error {
+	return e.err
+}
+
+// manifestSources returns the entries of bootstrap_manifests.
+// The second result is false while some entries are not known yet.
+func manifestSources(list types.List) ([]string, bool) {
+	if list.IsUnknown() {
+		return nil, false
+	}
+
+	sources := make([]string, 0, len(list.Elements()))
+
+	for _, element := range list.Elements() {
+		source, ok := element.(types.String)
+		if !ok || source.IsUnknown() {
+			return nil, false
+		}
+
+		sources = append(sources, source.ValueString())
+	}
+
+	return sources, true
+}
+
+// isInlineManifest reports whether a bootstrap_manifests entry holds inline YAML or JSON
+// rather than a file path. Inline manifests span several lines or are a JSON object.
+func isInlineManifest(source string) bool {
+	return strings.Contains(source, "\n") || strings.HasPrefix(strings.TrimSpace(source), "{")
+}
+
+// loadManifest returns the content of a bootstrap_manifests entry.
+func loadManifest(source string) (string, error) {
+	if isInlineManifest(source) {
+		return source, nil
+	}
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	return string(content), nil
+}
+
+// manifestsChecksum returns a digest of the manifest contents, so that changes to
+// referenced files show up in the plan even when the paths stay the same.
+func manifestsChecksum(sources []string) (string, error) {
+	hash := sha256.New()
+
+	for _, source := range sources {
+		content, err := loadManifest(source)
+		if err != nil {
+			return "", err
+		}
+
+		_, _ = fmt.Fprintf(hash, "%d:%s", len(content), content)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// decodeManifest decodes the YAML or JSON documents of a manifest into objects.
+// Empty documents are skipped and List objects are expanded into their items.
+func decodeManifest(content string) ([]*unstructured.Unstructured, error) {
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(content)), manifestDecodeBufferSize)
+
+	var objects []*unstructured.Unstructured
+
+	for {
+		var raw map[string]any
+
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+		}
+
+		if len(raw) == 0 {
+			continue
+		}
+
+		object := &unstructured.Unstructured{Object: raw}
+
+		if object.IsList() {
+			list, listErr := object.ToList()
+			if listErr != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, listErr)
+			}
+
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+
+			continue
+		}
+
+		if object.GetKind() == "" || object.GetAPIVersion() == "" || object.GetName() == "" {
+			return nil, fmt.Errorf("%w: every object needs apiVersion, kind and metadata.name", ErrInvalidManifest)
+		}
+
+		objects = append(objects, object)
+	}
+}
+
+// loadManifestObjects loads and decodes every manifest, ordered so that CRDs and
+// namespaces are applied before the objects that depend on them.
+func loadManifestObjects(sources []string) ([]manifestObject, error) {
+	var objects []manifestObject
+
+	for i, source := range sources {
+		content, err := loadManifest(source)
+		if err != nil {
+			return nil, fmt.Errorf("bootstrap_manifests[%d]: %w", i, err)
+		}
+
+		decoded, err := decodeManifest(content)
+		if err != nil {
+			return nil, fmt.Errorf("bootstrap_manifests[%d]: %w", i, err)
+		}
+
+		for _, object := range decoded {
+			objects = append(objects, manifestObject{object: object, source: i})
+		}
+	}
+
+	slices.SortStableFunc(objects, func(a, b manifestObject) int {
+		return manifestApplyRank(a.object) - manifestApplyRank(b.object)
+	})
+
+	return objects, nil
+}
+
+// manifestApplyRank orders object kinds for applying.
+func manifestApplyRank(object *unstructured.Unstructured) int {
+	switch object.GetKind() {
+	case "CustomResourceDefinition":
+		return 0
+	case "Namespace":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// manifestObjectName identifies an object in diagnostics, ex: Deployment kube-system/coredns.
+func manifestObjectName(object *unstructured.Unstructured) string {
+	if object.GetNamespace() == "" {
+		return object.GetKind() + " " + object.GetName()
+	}
+
+	return object.GetKind() + " " + object.GetNamespace() + "/" + object.GetName()
+}
+
+// newManifestClients creates the dynamic client and REST mapper used to apply manifests.
+func newManifestClients(restConfig *rest.Config) (dynamic.Interface, meta.ResettableRESTMapper, error) {
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	return dynamicClient, restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)), nil
+}
+
+// applyManifests server-side applies every object of the manifests and returns one error
+// per object that failed. Objects are applied even when earlier ones failed.
+func applyManifests(
+	ctx context.Context,
+	client dynamic.Interface,
+	mapper meta.ResettableRESTMapper,
+	objects []manifestObject,
+) []manifestApplyError {
+	var failures []manifestApplyError
+
+	for _, item := range objects {
+		err := applyManifestObject(ctx, client, mapper, item.object, manifestFieldManager)
+		if err != nil {
+			failures = append(failures, manifestApplyError{
+				source: item.source,
+				object: manifestObjectName(item.object),
+				err:    err,
+			})
+		}
+	}
+
+	return failures
+}
+
+// applyManifestObject server-side applies a single object. Kinds that are not served yet,
+// such as custom resources of a CRD applied just before, are retried while discovery catches up.
+func applyManifestObject(
+	ctx context.Context,
+	client dynamic.Interface,
+	mapper meta.ResettableRESTMapper,
+	object *unstructured.Unstructured,
+	fieldManager string,
+) error {
+	var resource dynamic.ResourceInterface
+
+	err := wait.PollUntilContextTimeout(ctx, manifestMappingInterval, manifestMappingTimeout, true,
+		func(context.Context) (bool, error) {
+			var mappingErr error
+
+			resource, mappingErr = manifestObjectResource(client, mapper, object)
+			if meta.IsNoMatchError(mappingErr) {
+				mapper.Reset()
+
+				return false, nil
+			}
+
+			return true, mappingErr
+		},
+	)
+	if wait.Interrupted(err) {
+		return fmt.Errorf("failed to resolve %s: %w", object.GroupVersionKind().String(), err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = resource.Apply(ctx, object.GetName(), object, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("server-side apply failed: %w", err)
+	}
+
+	return nil
+}
+
+// manifestObjectInSync reports whether the live object matches the manifest, that is
+// whether a dry-run apply of the manifest leaves it unchanged. Missing objects are not in sync.
+func manifestObjectInSync(
+	ctx context.Context,
+	client dynamic.Interface,
+	mapper meta.RESTMapper,
+	object *unstructured.Unstructured,
+	fieldManager string,
+) (bool, error) {
+	resource, err := manifestObjectResource(client, mapper, object)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	live, err := resource.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s: %w", manifestObjectName(object), err)
+	}
+
+	applied, err := resource.Apply(ctx, object.GetName(), object, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+		DryRun:       []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return false, fmt.Errorf("dry-run apply of %s failed: %w", manifestObjectName(object), err)
+	}
+
+	// Field ownership changes alone do not change the object
+	live = live.DeepCopy()
+	applied = applied.DeepCopy()
+	live.SetManagedFields(nil)
+	applied.SetManagedFields(nil)
+
+	return equality.Semantic.DeepEqual(live.Object, applied.Object), nil
+}
+
+// deleteManifestObject deletes an object. Objects or kinds that no longer exist are skipped.
+func deleteManifestObject(
+	ctx context.Context,
+	client dynamic.Interface,
+	mapper meta.RESTMapper,
+	object *unstructured.Unstructured,
+) error {
+	resource, err := manifestObjectResource(client, mapper, object)
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = resource.Delete(ctx, object.GetName(), metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s: %w", manifestObjectName(object), err)
+	}
+
+	return nil
+}
+
+// manifestObjectResource returns the dynamic client of the resource an object belongs to.
+// Namespaced objects without a namespace go to the default namespace.
+//
+//nolint:ireturn // the dynamic client returns interfaces
+func manifestObjectResource(
+	client dynamic.Interface,
+	mapper meta.RESTMapper,
+	object *unstructured.Unstructured,
+) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", gvk.String(), err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return client.Resource(mapping.Resource), nil
+	}
+
+	namespace := object.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	return client.Resource(mapping.Resource).Namespace(namespace), nil
+}
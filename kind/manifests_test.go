@@ -0,0 +1,277 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+const manifestTestNamespace = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cni
+`
+
+const manifestTestDaemonSet = `---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: cni
+  namespace: cni
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cni-config
+`
+
+// manifestTestMapper is a static REST mapper that satisfies meta.ResettableRESTMapper.
+type manifestTestMapper struct {
+	*meta.DefaultRESTMapper
+}
+
+func (manifestTestMapper) Reset() {}
+
+func newManifestTestMapper() manifestTestMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}, meta.RESTScopeNamespace)
+
+	return manifestTestMapper{DefaultRESTMapper: mapper}
+}
+
+func TestIsInlineManifest(t *testing.T) {
+	assert.True(t, isInlineManifest(manifestTestNamespace))
+	assert.True(t, isInlineManifest(` {"apiVersion": "v1"}`))
+	assert.False(t, isInlineManifest("manifests/calico.yaml"))
+}
+
+func TestManifestSources(t *testing.T) {
+	sources, known := manifestSources(types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("a.yaml"),
+		types.StringValue("b.yaml"),
+	}))
+	assert.True(t, known)
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, sources)
+
+	_, known = manifestSources(types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()}))
+	assert.False(t, known)
+
+	sources, known = manifestSources(types.ListNull(types.StringType))
+	assert.True(t, known)
+	assert.Empty(t, sources)
+}
+
+func TestManifestsChecksum(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "namespace.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(manifestTestNamespace), 0o600))
+
+	fromFile, err := manifestsChecksum([]string{file})
+	require.NoError(t, err)
+
+	inline, err := manifestsChecksum([]string{manifestTestNamespace})
+	require.NoError(t, err)
+	assert.Equal(t, inline, fromFile, "the checksum covers the content, not the path")
+
+	require.NoError(t, os.WriteFile(file, []byte(manifestTestDaemonSet), 0o600))
+
+	changed, err := manifestsChecksum([]string{file})
+	require.NoError(t, err)
+	assert.NotEqual(t, fromFile, changed)
+
+	_, err = manifestsChecksum([]string{filepath.Join(t.TempDir(), "missing.yaml")})
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestDecodeManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kinds   []string
+		wantErr bool
+	}{
+		{name: "multiple documents", content: manifestTestDaemonSet, kinds: []string{"DaemonSet", "ConfigMap"}},
+		{name: "json", content: `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "cni"}}`, kinds: []string{"Namespace"}},
+		{
+			name:    "list",
+			content: "apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: Namespace\n  metadata:\n    name: cni\n",
+			kinds:   []string{"Namespace"},
+		},
+		{name: "empty", content: "---\n---\n"},
+		{name: "missing name", content: "apiVersion: v1\nkind: Namespace\n", wantErr: true},
+		{name: "invalid yaml", content: "apiVersion: [v1\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := decodeManifest(tt.content)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidManifest)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			kinds := make([]string, 0, len(objects))
+			for _, object := range objects {
+				kinds = append(kinds, object.GetKind())
+			}
+
+			assert.ElementsMatch(t, tt.kinds, kinds)
+		})
+	}
+}
+
+func TestLoadManifestObjects(t *testing.T) {
+	objects, err := loadManifestObjects([]string{manifestTestDaemonSet, manifestTestNamespace})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(objects))
+	sources := make([]int, 0, len(objects))
+
+	for _, object := range objects {
+		names = append(names, manifestObjectName(object.object))
+		sources = append(sources, object.source)
+	}
+
+	assert.Equal(t, []string{"Namespace cni", "DaemonSet cni/cni", "ConfigMap cni-config"}, names)
+	assert.Equal(t, []int{1, 0, 0}, sources)
+
+	_, err = loadManifestObjects([]string{manifestTestNamespace, "apiVersion: v1\nkind: Namespace\n"})
+	require.ErrorIs(t, err, ErrInvalidManifest)
+	assert.Contains(t, err.Error(), "bootstrap_manifests[1]")
+}
+
+func TestApplyManifests(t *testing.T) {
+	objects, err := loadManifestObjects([]string{
+		manifestTestDaemonSet,
+		manifestTestNamespace,
+		"apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: unknown\n",
+	})
+	require.NoError(t, err)
+
+	client := dynamicfake.NewSimpleDynamicClient(k8sruntime.NewScheme())
+
+	var applied []string
+
+	client.PrependReactor("patch", "*", func(action clienttesting.Action) (bool, k8sruntime.Object, error) {
+		patch, ok := action.(clienttesting.PatchAction)
+		require.True(t, ok)
+
+		if patch.GetResource().Resource == "daemonsets" {
+			return true, nil, errors.New("admission denied")
+		}
+
+		applied = append(applied, patch.GetNamespace()+"/"+patch.GetName())
+
+		return true, &unstructured.Unstructured{}, nil
+	})
+
+	// The unknown kind is applied last and retried until the context expires
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	defer cancel()
+
+	failures := applyManifests(ctx, client, newManifestTestMapper(), objects)
+	require.Len(t, failures, 2)
+
+	assert.Equal(t, 0, failures[0].source)
+	assert.Contains(t, failures[0].Error(), "DaemonSet cni/cni: server-side apply failed: admission denied")
+	assert.Equal(t, 2, failures[1].source)
+	assert.Contains(t, failures[1].Error(), "Widget unknown: failed to resolve")
+
+	assert.Equal(t, []string{"/cni", "default/cni-config"}, applied, "namespaced objects default to the default namespace")
+}
+
+func TestManifestObjectInSync(t *testing.T) {
+	objects, err := decodeManifest(manifestTestDaemonSet)
+	require.NoError(t, err)
+
+	configMap := objects[1]
+
+	live := configMap.DeepCopy()
+	live.SetNamespace(metav1.NamespaceDefault)
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+
+	client := dynamicfake.NewSimpleDynamicClient(k8sruntime.NewScheme(), live)
+
+	dryRun := configMap.DeepCopy()
+	dryRun.SetNamespace(metav1.NamespaceDefault)
+
+	client.PrependReactor("patch", "configmaps", func(clienttesting.Action) (bool, k8sruntime.Object, error) {
+		return true, dryRun, nil
+	})
+
+	inSync, err := manifestObjectInSync(t.Context(), client, newManifestTestMapper(), configMap, manifestFieldManager)
+	require.NoError(t, err)
+	assert.True(t, inSync, "managed fields are ignored")
+
+	dryRun.SetLabels(map[string]string{"changed": "true"})
+
+	inSync, err = manifestObjectInSync(t.Context(), client, newManifestTestMapper(), configMap, manifestFieldManager)
+	require.NoError(t, err)
+	assert.False(t, inSync)
+
+	inSync, err = manifestObjectInSync(t.Context(), client, newManifestTestMapper(), objects[0], manifestFieldManager)
+	require.NoError(t, err)
+	assert.False(t, inSync, "missing objects are not in sync")
+}
+
+func TestDeleteManifestObject(t *testing.T) {
+	objects, err := decodeManifest(manifestTestDaemonSet)
+	require.NoError(t, err)
+
+	configMap := objects[1].DeepCopy()
+	configMap.SetNamespace(metav1.NamespaceDefault)
+
+	client := dynamicfake.NewSimpleDynamicClient(k8sruntime.NewScheme(), configMap)
+	mapper := newManifestTestMapper()
+
+	require.NoError(t, deleteManifestObject(t.Context(), client, mapper, objects[1]))
+
+	_, err = client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace(metav1.NamespaceDefault).
+		Get(t.Context(), configMap.GetName(), metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "the object is deleted from the default namespace")
+
+	require.NoError(t, deleteManifestObject(t.Context(), client, mapper, objects[1]), "missing objects are skipped")
+
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetName("removed")
+	require.NoError(t, deleteManifestObject(t.Context(), client, mapper, widget), "removed kinds are skipped")
+}
@@ -0,0 +1,361 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// defaultNetworkName is the network kind puts every cluster on, it is never removed.
+	defaultNetworkName = "kind"
+	// envDockerNetwork and envPodmanNetwork select the network kind creates the nodes on.
+	envDockerNetwork = "KIND_EXPERIMENTAL_DOCKER_NETWORK"
+	envPodmanNetwork = "KIND_EXPERIMENTAL_PODMAN_NETWORK"
+	// networkCreatedKey is the private state key recording that the cluster created its network,
+	// only such networks are removed with the cluster.
+	networkCreatedKey = "network_created"
+	// minNetworkMTU and maxNetworkMTU bound the network MTU.
+	minNetworkMTU = 68
+	maxNetworkMTU = 65535
+)
+
+// ErrInvalidNetwork is returned when a network block is invalid.
+//
+//nolint:grouper // false positive
+var ErrInvalidNetwork = errors.New("invalid network")
+
+// ErrNetworkNotFound is returned when a container network does not exist.
+//
+//nolint:grouper // false positive
+var ErrNetworkNotFound = errors.New("network not found")
+
+// clusterNetwork holds the settings of a network block.
+type clusterNetwork struct {
+	Name       string
+	IPv4Subnet string
+	IPv6Subnet string
+	MTU        int
+}
+
+// networkBlock returns the network block schema.
+// The nodes are attached to the network when they are created, so any change replaces the cluster.
+func networkBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "Container network of the nodes, created when it does not exist. A network the cluster created is removed on destroy once no container uses it, existing networks are left alone. Defaults to the shared 'kind' network.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the container network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv4_subnet": schema.StringAttribute{
+				Optional:    true,
+				Description: "IPv4 subnet of the network when the provider creates it (ex: 172.30.0.0/16).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv6_subnet": schema.StringAttribute{
+				Optional:    true,
+				Description: "IPv6 subnet of the network when the provider creates it, enables IPv6 (ex: fc00:f853:ccd:e793::/64).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mtu": schema.Int64Attribute{
+				Optional:    true,
+				Description: "MTU of the network when the provider creates it. Defaults to the runtime default.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// flattenNetwork converts a network block into a clusterNetwork, nil when the block is not set.
+func flattenNetwork(network types.Object) *clusterNetwork {
+	if network.IsNull() || network.IsUnknown() {
+		return nil
+	}
+
+	networkMap := objectToMap(network)
+
+	return &clusterNetwork{
+		Name:       getString(networkMap, "name"),
+		IPv4Subnet: getString(networkMap, "ipv4_subnet"),
+		IPv6Subnet: getString(networkMap, "ipv6_subnet"),
+		MTU:        getInt(networkMap, "mtu"),
+	}
+}
+
+// validate checks the network name, subnets and MTU.
+func (n *clusterNetwork) validate() error {
+	if strings.TrimSpace(n.Name) == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidNetwork)
+	}
+
+	for _, subnet := range []struct {
+		cidr   string
+		family string
+	}{{n.IPv4Subnet, "IPv4"}, {n.IPv6Subnet, "IPv6"}} {
+		if subnet.cidr == "" {
+			continue
+		}
+
+		prefix, _, err := net.ParseCIDR(subnet.cidr)
+		if err != nil || (prefix.To4() != nil) != (subnet.family == "IPv4") {
+			return fmt.Errorf("%w: %q is not an %s CIDR", ErrInvalidNetwork, subnet.cidr, subnet.family)
+		}
+	}
+
+	if n.MTU != 0 && (n.MTU < minNetworkMTU || n.MTU > maxNetworkMTU) {
+		return fmt.Errorf("%w: mtu must be between %d and %d", ErrInvalidNetwork, minNetworkMTU, maxNetworkMTU)
+	}
+
+	return nil
+}
+
+// env returns the environment variable that makes kind create the nodes on the network.
+func (n *clusterNetwork) env(binary string) map[string]string {
+	if binary == providerPodman {
+		return map[string]string{envPodmanNetwork: n.Name}
+	}
+
+	// nerdctl reads the docker variable as well
+	return map[string]string{envDockerNetwork: n.Name}
+}
+
+// createArgs returns the runtime CLI arguments creating the network.
+func (n *clusterNetwork) createArgs(binary string) []string {
+	args := []string{"network", "create", "--driver", "bridge"}
+
+	if n.IPv4Subnet != "" {
+		args = append(args, "--subnet", n.IPv4Subnet)
+	}
+
+	if n.IPv6Subnet != "" {
+		args = append(args, "--ipv6", "--subnet", n.IPv6Subnet)
+	}
+
+	if n.MTU != 0 {
+		mtuOption := "com.docker.network.driver.mtu="
+		if binary == providerPodman {
+			mtuOption = "mtu="
+		}
+
+		args = append(args, "--opt", mtuOption+strconv.Itoa(n.MTU))
+	}
+
+	return append(args, n.Name)
+}
+
+// networkExists reports whether the container network exists.
+func (r *kindRuntime) networkExists(ctx context.Context, name string) (bool, error) {
+	output, err := r.containerCommand(ctx, "network", "ls", "--format", "{{.Name}}")
+	if err != nil {
+		return false, err
+	}
+
+	for line := range strings.Lines(output) {
+		if strings.TrimSpace(line) == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ensureNetwork creates the container network unless it already exists.
+// It reports whether the network was created.
+func (r *kindRuntime) ensureNetwork(ctx context.Context, network *clusterNetwork) (bool, error) {
+	exists, err := r.networkExists(ctx, network.Name)
+	if err != nil || exists {
+		return false, err
+	}
+
+	binary, err := runtimeBinary(r.name)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = r.containerCommand(ctx, network.createArgs(binary)...)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// networkContainers returns the names of the containers attached to the network, sorted.
+func (r *kindRuntime) networkContainers(ctx context.Context, name string) ([]string, error) {
+	output, err := r.containerCommand(ctx, "ps", "--all", "--filter", "network="+name, "--format", "{{.Names}}")
+	if err != nil {
+		return nil, err
+	}
+
+	containers := strings.Fields(output)
+	slices.Sort(containers)
+
+	return containers, nil
+}
+
+// removeNetworkIfUnused removes the container network unless a container still uses it or it is
+// the shared kind network. It reports whether the network was removed.
+func (r *kindRuntime) removeNetworkIfUnused(ctx context.Context, name string) (bool, error) {
+	if name == defaultNetworkName {
+		return false, nil
+	}
+
+	exists, err := r.networkExists(ctx, name)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	containers, err := r.networkContainers(ctx, name)
+	if err != nil || len(containers) > 0 {
+		return false, err
+	}
+
+	_, err = r.containerCommand(ctx, "network", "rm", name)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// networkSubnet is a subnet of a container network.
+type networkSubnet struct {
+	Subnet  string `json:"subnet"`
+	Gateway string `json:"gateway"`
+}
+
+// networkInfo describes an existing container network.
+type networkInfo struct {
+	ID         string
+	Name       string
+	Subnets    []networkSubnet
+	Containers []string
+	MTU        int
+}
+
+// networkInspect is the part of the network inspect output read by the provider. JSON keys
+// match case-insensitively, so it decodes the docker and nerdctl format (IPAM.Config) as well
+// as the podman one (subnets).
+type networkInspect struct {
+	Options map[string]string `json:"options"`
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	IPAM    struct {
+		Config []networkSubnet `json:"config"`
+	} `json:"ipam"`
+	Subnets []networkSubnet `json:"subnets"`
+}
+
+// parseNetworkInspect decodes the output of a network inspect of a single network.
+func parseNetworkInspect(output string) (*networkInfo, error) {
+	var inspected []networkInspect
+
+	err := json.Unmarshal([]byte(output), &inspected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode network inspect output: %w", err)
+	}
+
+	if len(inspected) != 1 {
+		return nil, fmt.Errorf("%w: expected one network, got %d", ErrNetworkNotFound, len(inspected))
+	}
+
+	network := inspected[0]
+	info := &networkInfo{
+		ID:      network.ID,
+		Name:    network.Name,
+		Subnets: append(network.IPAM.Config, network.Subnets...),
+	}
+
+	for _, option := range []string{"com.docker.network.driver.mtu", "mtu"} {
+		if mtu, err := strconv.Atoi(network.Options[option]); err == nil {
+			info.MTU = mtu
+
+			break
+		}
+	}
+
+	return info, nil
+}
+
+// subnet returns the first subnet of the IPv4 or IPv6 family, or an empty subnet.
+func (n *networkInfo) subnet(ipv6 bool) networkSubnet {
+	for _, subnet := range n.Subnets {
+		prefix, _, err := net.ParseCIDR(subnet.Subnet)
+		if err == nil && (prefix.To4() == nil) == ipv6 {
+			return subnet
+		}
+	}
+
+	return networkSubnet{}
+}
+
+// inspectNetwork describes the container network, including the containers attached to it.
+// It returns ErrNetworkNotFound when the network does not exist.
+func (r *kindRuntime) inspectNetwork(ctx context.Context, name string) (*networkInfo, error) {
+	exists, err := r.networkExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNetworkNotFound, name)
+	}
+
+	output, err := r.containerCommand(ctx, "network", "inspect", name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseNetworkInspect(output)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Containers, err = r.networkContainers(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
@@ -0,0 +1,295 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRuntimeCLI writes a runtime CLI stub that records its arguments and prints the output
+// configured for the first two of them (ex: "network ls"), and returns a runtime calling it.
+func fakeRuntimeCLI(t *testing.T, outputs map[string]string) (*kindRuntime, func() []string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "calls.log")
+
+	var script strings.Builder
+
+	script.WriteString("#!/bin/sh\necho \"$*\" >> '" + logPath + "'\ncase \"$1 $2\" in\n")
+
+	for command, output := range outputs {
+		outputPath := filepath.Join(dir, strconv.Itoa(len(script.String()))+".out")
+		require.NoError(t, os.WriteFile(outputPath, []byte(output), 0o600))
+
+		script.WriteString("  \"" + command + "\") cat '" + outputPath + "' ;;\n")
+	}
+
+	script.WriteString("esac\n")
+
+	binary := filepath.Join(dir, "docker")
+	require.NoError(t, os.WriteFile(binary, []byte(script.String()), 0o755)) //nolint:gosec // test executable
+
+	calls := func() []string {
+		data, err := os.ReadFile(logPath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		require.NoError(t, err)
+
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	return &kindRuntime{name: binary, env: map[string]string{}}, calls
+}
+
+func TestFlattenNetwork(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		"name":        types.StringType,
+		"ipv4_subnet": types.StringType,
+		"ipv6_subnet": types.StringType,
+		"mtu":         types.Int64Type,
+	}
+
+	assert.Nil(t, flattenNetwork(types.ObjectNull(attrTypes)))
+
+	network, diags := types.ObjectValue(attrTypes, map[string]attr.Value{
+		"name":        types.StringValue("team-a"),
+		"ipv4_subnet": types.StringValue("172.30.0.0/16"),
+		"ipv6_subnet": types.StringNull(),
+		"mtu":         types.Int64Value(1400),
+	})
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, &clusterNetwork{Name: "team-a", IPv4Subnet: "172.30.0.0/16", MTU: 1400}, flattenNetwork(network))
+}
+
+func TestClusterNetworkValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		network clusterNetwork
+		wantErr bool
+	}{
+		{name: "name only", network: clusterNetwork{Name: "team-a"}},
+		{
+			name:    "dual stack",
+			network: clusterNetwork{Name: "team-a", IPv4Subnet: "172.30.0.0/16", IPv6Subnet: "fd00:30::/64", MTU: 1400},
+		},
+		{name: "empty name", network: clusterNetwork{Name: " "}, wantErr: true},
+		{name: "invalid ipv4 subnet", network: clusterNetwork{Name: "team-a", IPv4Subnet: "172.30.0.0"}, wantErr: true},
+		{name: "ipv6 as ipv4 subnet", network: clusterNetwork{Name: "team-a", IPv4Subnet: "fd00:30::/64"}, wantErr: true},
+		{name: "ipv4 as ipv6 subnet", network: clusterNetwork{Name: "team-a", IPv6Subnet: "172.30.0.0/16"}, wantErr: true},
+		{name: "mtu too small", network: clusterNetwork{Name: "team-a", MTU: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.network.validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidNetwork)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClusterNetworkEnv(t *testing.T) {
+	network := clusterNetwork{Name: "team-a"}
+
+	assert.Equal(t, map[string]string{envDockerNetwork: "team-a"}, network.env(providerDocker))
+	assert.Equal(t, map[string]string{envDockerNetwork: "team-a"}, network.env(providerNerdctl))
+	assert.Equal(t, map[string]string{envPodmanNetwork: "team-a"}, network.env(providerPodman))
+}
+
+func TestClusterNetworkCreateArgs(t *testing.T) {
+	network := clusterNetwork{Name: "team-a", IPv4Subnet: "172.30.0.0/16", IPv6Subnet: "fd00:30::/64", MTU: 1400}
+
+	assert.Equal(t, []string{
+		"network", "create", "--driver", "bridge",
+		"--subnet", "172.30.0.0/16",
+		"--ipv6", "--subnet", "fd00:30::/64",
+		"--opt", "com.docker.network.driver.mtu=1400",
+		"team-a",
+	}, network.createArgs(providerDocker))
+	assert.Contains(t, network.createArgs(providerPodman), "mtu=1400")
+	assert.Equal(t, []string{"network", "create", "--driver", "bridge", "team-b"},
+		(&clusterNetwork{Name: "team-b"}).createArgs(providerDocker))
+}
+
+func TestEnsureNetwork(t *testing.T) {
+	network := &clusterNetwork{Name: "team-a"}
+
+	runtime, calls := fakeRuntimeCLI(t, map[string]string{"network ls": "bridge\nteam-a\n"})
+	created, err := runtime.ensureNetwork(context.Background(), network)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, []string{"network ls --format {{.Name}}"}, calls())
+
+	runtime, calls = fakeRuntimeCLI(t, map[string]string{"network ls": "bridge\nkind\n"})
+	created, err = runtime.ensureNetwork(context.Background(), network)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, []string{"network ls --format {{.Name}}", "network create --driver bridge team-a"}, calls())
+}
+
+func TestRemoveNetworkIfUnused(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     string
+		networks    string
+		containers  string
+		wantRemoved bool
+	}{
+		{name: "unused", network: "team-a", networks: "team-a\n", wantRemoved: true},
+		{name: "in use", network: "team-a", networks: "team-a\n", containers: "dev-control-plane\n"},
+		{name: "missing", network: "team-a", networks: "kind\n"},
+		{name: "shared kind network", network: defaultNetworkName, networks: "kind\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime, calls := fakeRuntimeCLI(t, map[string]string{
+				"network ls": tt.networks,
+				"ps --all":   tt.containers,
+			})
+
+			removed, err := runtime.removeNetworkIfUnused(context.Background(), tt.network)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemoved, removed)
+
+			if tt.wantRemoved {
+				assert.Contains(t, calls(), "network rm "+tt.network)
+			} else {
+				assert.NotContains(t, calls(), "network rm "+tt.network)
+			}
+		})
+	}
+}
+
+//nolint:gochecknoglobals // test fixture
+var (
+	dockerNetworkInspect = `[{
+		"Name": "kind",
+		"Id": "0f3c6d7e",
+		"EnableIPv6": true,
+		"IPAM": {"Driver": "default", "Config": [
+			{"Subnet": "fc00:f853:ccd:e793::/64", "Gateway": "fc00:f853:ccd:e793::1"},
+			{"Subnet": "172.18.0.0/16", "Gateway": "172.18.0.1"}
+		]},
+		"Options": {"com.docker.network.bridge.enable_ip_masquerade": "true", "com.docker.network.driver.mtu": "1500"}
+	}]`
+	podmanNetworkInspect = `[{
+		"name": "kind",
+		"id": "9a8b7c6d",
+		"driver": "bridge",
+		"subnets": [{"subnet": "10.89.0.0/24", "gateway": "10.89.0.1"}],
+		"ipv6_enabled": false,
+		"options": {"mtu": "1400"}
+	}]`
+)
+
+func TestParseNetworkInspect(t *testing.T) {
+	info, err := parseNetworkInspect(dockerNetworkInspect)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0f3c6d7e", info.ID)
+	assert.Equal(t, "kind", info.Name)
+	assert.Equal(t, 1500, info.MTU)
+	assert.Equal(t, networkSubnet{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"}, info.subnet(false))
+	assert.Equal(t, networkSubnet{Subnet: "fc00:f853:ccd:e793::/64", Gateway: "fc00:f853:ccd:e793::1"}, info.subnet(true))
+
+	info, err = parseNetworkInspect(podmanNetworkInspect)
+	require.NoError(t, err)
+
+	assert.Equal(t, "9a8b7c6d", info.ID)
+	assert.Equal(t, 1400, info.MTU)
+	assert.Equal(t, networkSubnet{Subnet: "10.89.0.0/24", Gateway: "10.89.0.1"}, info.subnet(false))
+	assert.Equal(t, networkSubnet{}, info.subnet(true))
+
+	_, err = parseNetworkInspect("[]")
+	require.ErrorIs(t, err, ErrNetworkNotFound)
+
+	_, err = parseNetworkInspect("Error: no such network")
+	require.Error(t, err)
+}
+
+func TestInspectNetwork(t *testing.T) {
+	runtime, _ := fakeRuntimeCLI(t, map[string]string{
+		"network ls":      "bridge\nkind\n",
+		"network inspect": dockerNetworkInspect,
+		"ps --all":        "dev-worker\ndev-control-plane\n",
+	})
+
+	info, err := runtime.inspectNetwork(context.Background(), "kind")
+	require.NoError(t, err)
+
+	assert.Equal(t, "0f3c6d7e", info.ID)
+	assert.Equal(t, []string{"dev-control-plane", "dev-worker"}, info.Containers)
+
+	_, err = runtime.inspectNetwork(context.Background(), "team-a")
+	require.ErrorIs(t, err, ErrNetworkNotFound)
+}
+
+func TestRemoveClusterNetwork(t *testing.T) {
+	network := &clusterNetwork{Name: "dev-net"}
+
+	tests := []struct {
+		network     *clusterNetwork
+		name        string
+		created     bool
+		wantRemoved bool
+	}{
+		{name: "created by the cluster", network: network, created: true, wantRemoved: true},
+		{name: "existing network", network: network},
+		{name: "shared kind network", created: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime, calls := fakeRuntimeCLI(t, map[string]string{
+				"network ls": "kind\ndev-net\n",
+				"ps --all":   "",
+			})
+
+			var diags diag.Diagnostics
+
+			removeClusterNetwork(context.Background(), runtime, tt.network, tt.created, &diags)
+			require.False(t, diags.HasError())
+
+			if tt.wantRemoved {
+				assert.Contains(t, calls(), "network rm dev-net")
+			} else {
+				assert.Empty(t, calls())
+			}
+		})
+	}
+}
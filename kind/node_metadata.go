@@ -0,0 +1,446 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// ErrInvalidTaint is returned when a node taint has a missing key or an unsupported effect.
+//
+//nolint:grouper // false positive
+var ErrInvalidTaint = errors.New("invalid taint")
+
+// taintEffects lists the taint effects supported by Kubernetes.
+var taintEffects = []string{
+	string(corev1.TaintEffectNoSchedule),
+	string(corev1.TaintEffectPreferNoSchedule),
+	string(corev1.TaintEffectNoExecute),
+}
+
+// nodeMetadata holds the labels and taints managed on a Kubernetes Node object.
+// A nil field means the attribute is not set and therefore not managed.
+type nodeMetadata struct {
+	Labels map[string]string
+	Taints []corev1.Taint
+}
+
+// flattenNodeTaints converts the taints of a node block into Kubernetes taints.
+// Returns nil if the node declares no taints.
+func flattenNodeTaints(nodeConfig map[string]any) []corev1.Taint {
+	val, exists := nodeConfig["taints"]
+	if !exists || val == nil {
+		return nil
+	}
+
+	taintMaps := getMapSlice(nodeConfig, "taints")
+	taints := make([]corev1.Taint, 0, len(taintMaps))
+
+	for _, taintMap := range taintMaps {
+		taints = append(taints, corev1.Taint{
+			Key:    getString(taintMap, "key"),
+			Value:  getString(taintMap, "value"),
+			Effect: corev1.TaintEffect(getString(taintMap, "effect")),
+		})
+	}
+
+	return taints
+}
+
+// validateTaint checks that a taint has a key and a supported effect.
+func validateTaint(taint corev1.Taint) error {
+	if taint.Key == "" {
+		return fmt.Errorf("%w: key must not be empty", ErrInvalidTaint)
+	}
+
+	if !slices.Contains(taintEffects, string(taint.Effect)) {
+		return fmt.Errorf("%w: effect %q must be one of %v", ErrInvalidTaint, taint.Effect, taintEffects)
+	}
+
+	return nil
+}
+
+// kindNodeNames returns the Kubernetes node names kind assigns to the node blocks,
+// in order. kind numbers nodes per role, starting the suffix at 2 for the second node.
+func kindNodeNames(clusterName string, nodeConfigs []map[string]any) []string {
+	counter := make(map[string]int)
+	names := make([]string, 0, len(nodeConfigs))
+
+	for _, nodeConfig := range nodeConfigs {
+		role := getString(nodeConfig, "role")
+		if role == "" {
+			role = string(v1alpha4.ControlPlaneRole)
+		}
+
+		counter[role]++
+
+		suffix := ""
+		if counter[role] > 1 {
+			suffix = strconv.Itoa(counter[role])
+		}
+
+		names = append(names, fmt.Sprintf("%s-%s%s", clusterName, role, suffix))
+	}
+
+	return names
+}
+
+// declaredNodeMetadata returns the labels and taints declared in kind_config,
+// keyed by Kubernetes node name. Nodes without labels or taints are omitted.
+func declaredNodeMetadata(clusterName string, kindConfig map[string]any) map[string]nodeMetadata {
+	nodeConfigs := getMapSlice(kindConfig, "node")
+	names := kindNodeNames(clusterName, nodeConfigs)
+	declared := make(map[string]nodeMetadata)
+
+	for i, nodeConfig := range nodeConfigs {
+		metadata := nodeMetadata{
+			Labels: getStringMap(nodeConfig, "labels"),
+			Taints: flattenNodeTaints(nodeConfig),
+		}
+
+		if metadata.Labels == nil && metadata.Taints == nil {
+			continue
+		}
+
+		declared[names[i]] = metadata
+	}
+
+	return declared
+}
+
+// taintMatches reports whether two taints target the same key and effect.
+func taintMatches(a, b corev1.Taint) bool {
+	return a.Key == b.Key && a.Effect == b.Effect
+}
+
+// mergeNodeLabels returns a merge patch for the node labels: desired labels are set
+// and labels that were previously managed but are no longer desired are removed.
+func mergeNodeLabels(current, desired, previous map[string]string) map[string]any {
+	patch := make(map[string]any)
+
+	for key := range previous {
+		if _, keep := desired[key]; !keep {
+			if _, exists := current[key]; exists {
+				patch[key] = nil
+			}
+		}
+	}
+
+	for key, value := range desired {
+		if current[key] != value {
+			patch[key] = value
+		}
+	}
+
+	return patch
+}
+
+// mergeNodeTaints returns the full taint list of a node: taints that were previously
+// managed but are no longer desired are removed, desired taints are added or updated,
+// and taints managed by anyone else are kept.
+func mergeNodeTaints(current, desired, previous []corev1.Taint) []corev1.Taint {
+	merged := make([]corev1.Taint, 0, len(current)+len(desired))
+
+	for _, taint := range current {
+		managed := slices.ContainsFunc(previous, func(p corev1.Taint) bool { return taintMatches(p, taint) })
+		wanted := slices.ContainsFunc(desired, func(d corev1.Taint) bool { return taintMatches(d, taint) })
+
+		// Desired taints are re-added below, dropped managed taints are not
+		if managed || wanted {
+			continue
+		}
+
+		merged = append(merged, taint)
+	}
+
+	return append(merged, desired...)
+}
+
+// taintsEqual reports whether two taint lists hold the same taints, ignoring order.
+func taintsEqual(a, b []corev1.Taint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, taint := range a {
+		if !slices.ContainsFunc(b, func(other corev1.Taint) bool {
+			return taintMatches(taint, other) && taint.Value == other.Value
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reconcileNodeMetadata patches the Node objects so that their managed labels and taints
+// match desired. previous holds what was managed before, so that removed entries are deleted.
+func reconcileNodeMetadata(
+	ctx context.Context,
+	client kubernetes.Interface,
+	desired, previous map[string]nodeMetadata,
+) error {
+	names := maps.Clone(desired)
+	maps.Copy(names, previous)
+
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return patchNodeMetadata(ctx, client, name, desired[name], previous[name])
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update labels and taints of node %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// patchNodeMetadata applies a single merge patch to a Node object.
+// The resource version guards the full taint list against concurrent changes.
+func patchNodeMetadata(
+	ctx context.Context,
+	client kubernetes.Interface,
+	name string,
+	desired, previous nodeMetadata,
+) error {
+	node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+
+	labels := mergeNodeLabels(node.Labels, desired.Labels, previous.Labels)
+	taints := mergeNodeTaints(node.Spec.Taints, desired.Taints, previous.Taints)
+
+	if len(labels) == 0 && taintsEqual(taints, node.Spec.Taints) {
+		return nil
+	}
+
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": node.ResourceVersion,
+			"labels":          labels,
+		},
+		"spec": map[string]any{
+			"taints": taints,
+		},
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode node patch: %w", err)
+	}
+
+	_, err = client.CoreV1().Nodes().Patch(ctx, name, k8stypes.MergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch node: %w", err)
+	}
+
+	return nil
+}
+
+// observeNodeMetadata reads the labels and taints of the declared nodes.
+// Nodes that no longer exist are omitted.
+func observeNodeMetadata(
+	ctx context.Context,
+	client kubernetes.Interface,
+	declared map[string]nodeMetadata,
+) (map[string]nodeMetadata, error) {
+	observed := make(map[string]nodeMetadata, len(declared))
+
+	for _, name := range slices.Sorted(maps.Keys(declared)) {
+		node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+
+		observed[name] = nodeMetadata{Labels: node.Labels, Taints: node.Spec.Taints}
+	}
+
+	return observed, nil
+}
+
+// setObservedNodeMetadata writes the observed labels and taints back into kind_config,
+// so that drift shows up in the plan. Only declared keys and taints are reported;
+// everything else on the Node objects is owned by Kubernetes or other tools.
+func setObservedNodeMetadata(
+	ctx context.Context,
+	kindConfigList types.List,
+	clusterName string,
+	observed map[string]nodeMetadata,
+) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	kindConfigObj, ok := firstObject(kindConfigList)
+	if !ok {
+		return kindConfigList, diags
+	}
+
+	kindConfigAttrs := kindConfigObj.Attributes()
+
+	nodeList, ok := kindConfigAttrs["node"].(types.List)
+	if !ok || nodeList.IsNull() || nodeList.IsUnknown() {
+		return kindConfigList, diags
+	}
+
+	names := kindNodeNames(clusterName, getMapSlice(objectToMap(kindConfigObj), "node"))
+	nodeElements := slices.Clone(nodeList.Elements())
+
+	for i, element := range nodeElements {
+		nodeObj, isObject := element.(types.Object)
+		actual, found := observed[names[i]]
+
+		if !isObject || !found {
+			continue
+		}
+
+		nodeAttrs := maps.Clone(nodeObj.Attributes())
+
+		if labels, isMap := nodeAttrs["labels"].(types.Map); isMap && !labels.IsNull() && !labels.IsUnknown() {
+			nodeAttrs["labels"] = observedLabels(labels, actual.Labels)
+		}
+
+		if taints, isList := nodeAttrs["taints"].(types.List); isList && !taints.IsNull() && !taints.IsUnknown() {
+			nodeAttrs["taints"] = observedTaints(ctx, taints, actual.Taints, &diags)
+		}
+
+		rebuilt, objDiags := types.ObjectValue(nodeObj.AttributeTypes(ctx), nodeAttrs)
+		diags.Append(objDiags...)
+
+		nodeElements[i] = rebuilt
+	}
+
+	if diags.HasError() {
+		return kindConfigList, diags
+	}
+
+	rebuiltNodes, listDiags := types.ListValue(nodeList.ElementType(ctx), nodeElements)
+	diags.Append(listDiags...)
+
+	kindConfigAttrs = maps.Clone(kindConfigAttrs)
+	kindConfigAttrs["node"] = rebuiltNodes
+
+	rebuiltKindConfig, objDiags := types.ObjectValue(kindConfigObj.AttributeTypes(ctx), kindConfigAttrs)
+	diags.Append(objDiags...)
+
+	if diags.HasError() {
+		return kindConfigList, diags
+	}
+
+	result, listDiags := types.ListValue(kindConfigList.ElementType(ctx), []attr.Value{rebuiltKindConfig})
+	diags.Append(listDiags...)
+
+	return result, diags
+}
+
+// observedLabels returns the declared label keys with their actual values.
+// Keys missing from the node are dropped.
+func observedLabels(declared types.Map, actual map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(declared.Elements()))
+
+	for key := range declared.Elements() {
+		if value, exists := actual[key]; exists {
+			elements[key] = types.StringValue(value)
+		}
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// observedTaints returns the declared taints with their actual values, in declared order.
+// Taints missing from the node are dropped.
+func observedTaints(
+	ctx context.Context,
+	declared types.List,
+	actual []corev1.Taint,
+	diags *diag.Diagnostics,
+) types.List {
+	elements := make([]attr.Value, 0, len(declared.Elements()))
+
+	for _, element := range declared.Elements() {
+		taintObj, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+
+		taintAttrs := taintObj.Attributes()
+		want := corev1.Taint{
+			Key:    attrValueToString(taintAttrs["key"]),
+			Effect: corev1.TaintEffect(attrValueToString(taintAttrs["effect"])),
+		}
+
+		index := slices.IndexFunc(actual, func(taint corev1.Taint) bool { return taintMatches(want, taint) })
+		if index < 0 {
+			continue
+		}
+
+		taintAttrs = maps.Clone(taintAttrs)
+
+		// An unset value and an empty value are the same taint
+		if value, isString := taintAttrs["value"].(types.String); !isString || !value.IsNull() || actual[index].Value != "" {
+			taintAttrs["value"] = types.StringValue(actual[index].Value)
+		}
+
+		rebuilt, objDiags := types.ObjectValue(taintObj.AttributeTypes(ctx), taintAttrs)
+		diags.Append(objDiags...)
+
+		elements = append(elements, rebuilt)
+	}
+
+	result, listDiags := types.ListValue(declared.ElementType(ctx), elements)
+	diags.Append(listDiags...)
+
+	return result
+}
+
+// firstObject returns the first element of a list of objects.
+func firstObject(list types.List) (types.Object, bool) {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return types.Object{}, false
+	}
+
+	obj, ok := list.Elements()[0].(types.Object)
+
+	return obj, ok
+}
+
+// attrValueToString returns the string held by a Framework value, or an empty string.
+func attrValueToString(value attr.Value) string {
+	s, _ := attrValueToAny(value).(string)
+
+	return s
+}
@@ -0,0 +1,265 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKindNodeNames(t *testing.T) {
+	nodeConfigs := []map[string]any{
+		{"role": "control-plane"},
+		{"role": "worker"},
+		{"role": "worker"},
+		{},
+		{"role": "worker"},
+	}
+
+	assert.Equal(t, []string{
+		"test-control-plane",
+		"test-worker",
+		"test-worker2",
+		"test-control-plane2",
+		"test-worker3",
+	}, kindNodeNames("test", nodeConfigs))
+}
+
+func TestDeclaredNodeMetadata(t *testing.T) {
+	kindConfig := map[string]any{
+		"node": []any{
+			map[string]any{"role": "control-plane"},
+			map[string]any{
+				"role":   "worker",
+				"labels": map[string]any{"tier": "frontend"},
+				"taints": []any{
+					map[string]any{"key": "dedicated", "value": "frontend", "effect": "NoSchedule"},
+				},
+			},
+			map[string]any{"role": "worker", "taints": []any{}},
+		},
+	}
+
+	declared := declaredNodeMetadata("test", kindConfig)
+	require.Len(t, declared, 2, "nodes without labels or taints are not managed")
+
+	assert.Equal(t, map[string]string{"tier": "frontend"}, declared["test-worker"].Labels)
+	assert.Equal(t, []corev1.Taint{
+		{Key: "dedicated", Value: "frontend", Effect: corev1.TaintEffectNoSchedule},
+	}, declared["test-worker"].Taints)
+	assert.Nil(t, declared["test-worker2"].Labels)
+	assert.Empty(t, declared["test-worker2"].Taints, "an empty taint list is still managed")
+}
+
+func TestValidateTaint(t *testing.T) {
+	tests := []struct {
+		name    string
+		taint   corev1.Taint
+		wantErr bool
+	}{
+		{
+			name:  "valid taint",
+			taint: corev1.Taint{Key: "dedicated", Effect: corev1.TaintEffectNoExecute},
+		},
+		{
+			name:    "missing key",
+			taint:   corev1.Taint{Effect: corev1.TaintEffectNoSchedule},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported effect",
+			taint:   corev1.Taint{Key: "dedicated", Effect: "Sometimes"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTaint(tt.taint)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTaint)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMergeNodeLabels(t *testing.T) {
+	current := map[string]string{"kubernetes.io/hostname": "test-worker", "tier": "frontend", "old": "x"}
+	desired := map[string]string{"tier": "backend", "zone": "a"}
+	previous := map[string]string{"tier": "frontend", "old": "x"}
+
+	assert.Equal(t, map[string]any{
+		"tier": "backend",
+		"zone": "a",
+		"old":  nil,
+	}, mergeNodeLabels(current, desired, previous))
+}
+
+func TestMergeNodeTaints(t *testing.T) {
+	external := corev1.Taint{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoExecute}
+	removed := corev1.Taint{Key: "old", Effect: corev1.TaintEffectNoSchedule}
+	updated := corev1.Taint{Key: "dedicated", Value: "backend", Effect: corev1.TaintEffectNoSchedule}
+
+	current := []corev1.Taint{
+		external,
+		removed,
+		{Key: "dedicated", Value: "frontend", Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	merged := mergeNodeTaints(current, []corev1.Taint{updated}, []corev1.Taint{removed})
+	assert.Equal(t, []corev1.Taint{external, updated}, merged)
+	assert.True(t, taintsEqual(merged, []corev1.Taint{updated, external}), "order should not matter")
+	assert.False(t, taintsEqual(merged, current))
+}
+
+func TestReconcileNodeMetadata(t *testing.T) {
+	client := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-worker",
+			Labels: map[string]string{"kubernetes.io/hostname": "test-worker", "tier": "frontend"},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "old", Effect: corev1.TaintEffectNoSchedule}},
+		},
+	})
+
+	desired := map[string]nodeMetadata{
+		"test-worker": {
+			Labels: map[string]string{"zone": "a"},
+			Taints: []corev1.Taint{{Key: "dedicated", Value: "backend", Effect: corev1.TaintEffectNoExecute}},
+		},
+	}
+	previous := map[string]nodeMetadata{
+		"test-worker": {
+			Labels: map[string]string{"tier": "frontend"},
+			Taints: []corev1.Taint{{Key: "old", Effect: corev1.TaintEffectNoSchedule}},
+		},
+	}
+
+	require.NoError(t, reconcileNodeMetadata(t.Context(), client, desired, previous))
+
+	observed, err := observeNodeMetadata(t.Context(), client, desired)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": "test-worker", "zone": "a"}, observed["test-worker"].Labels)
+	assert.Equal(t, desired["test-worker"].Taints, observed["test-worker"].Taints)
+
+	err = reconcileNodeMetadata(t.Context(), client, map[string]nodeMetadata{"test-missing": {}}, nil)
+	require.Error(t, err, "missing nodes cannot be updated")
+
+	observed, err = observeNodeMetadata(t.Context(), client, map[string]nodeMetadata{"test-missing": {}})
+	require.NoError(t, err)
+	assert.Empty(t, observed, "missing nodes are not reported")
+}
+
+func TestSetObservedNodeMetadata(t *testing.T) {
+	taintType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"key":    types.StringType,
+		"value":  types.StringType,
+		"effect": types.StringType,
+	}}
+	nodeType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"role":   types.StringType,
+		"labels": types.MapType{ElemType: types.StringType},
+		"taints": types.ListType{ElemType: taintType},
+	}}
+	kindConfigType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"node": types.ListType{ElemType: nodeType},
+	}}
+
+	taint := func(key string, value types.String) attr.Value {
+		return types.ObjectValueMust(taintType.AttrTypes, map[string]attr.Value{
+			"key":    types.StringValue(key),
+			"value":  value,
+			"effect": types.StringValue("NoSchedule"),
+		})
+	}
+
+	node := func(role string, labels types.Map, taints types.List) attr.Value {
+		return types.ObjectValueMust(nodeType.AttrTypes, map[string]attr.Value{
+			"role":   types.StringValue(role),
+			"labels": labels,
+			"taints": taints,
+		})
+	}
+
+	kindConfig := func(nodes ...attr.Value) types.List {
+		return types.ListValueMust(kindConfigType, []attr.Value{
+			types.ObjectValueMust(kindConfigType.AttrTypes, map[string]attr.Value{
+				"node": types.ListValueMust(nodeType, nodes),
+			}),
+		})
+	}
+
+	declared := kindConfig(
+		node("control-plane", types.MapNull(types.StringType), types.ListNull(taintType)),
+		node(
+			"worker",
+			types.MapValueMust(types.StringType, map[string]attr.Value{
+				"tier": types.StringValue("frontend"),
+				"zone": types.StringValue("a"),
+			}),
+			types.ListValueMust(taintType, []attr.Value{
+				taint("dedicated", types.StringNull()),
+				taint("gpu", types.StringValue("true")),
+			}),
+		),
+	)
+
+	observed := map[string]nodeMetadata{
+		"test-worker": {
+			Labels: map[string]string{"kubernetes.io/hostname": "test-worker", "tier": "backend"},
+			Taints: []corev1.Taint{
+				{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	result, diags := setObservedNodeMetadata(t.Context(), declared, "test", observed)
+	require.False(t, diags.HasError(), "diagnostics: %v", diags)
+
+	expected := kindConfig(
+		node("control-plane", types.MapNull(types.StringType), types.ListNull(taintType)),
+		node(
+			"worker",
+			types.MapValueMust(types.StringType, map[string]attr.Value{
+				"tier": types.StringValue("backend"),
+			}),
+			types.ListValueMust(taintType, []attr.Value{
+				taint("dedicated", types.StringNull()),
+			}),
+		),
+	)
+
+	assert.True(t, expected.Equal(result), "expected %s, got %s", expected, result)
+
+	unchanged, diags := setObservedNodeMetadata(t.Context(), types.ListNull(kindConfigType), "test", observed)
+	require.False(t, diags.HasError())
+	assert.True(t, unchanged.IsNull())
+}
@@ -0,0 +1,417 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	kindexec "sigs.k8s.io/kind/pkg/exec"
+)
+
+// ErrNoRuntimeDetected is returned when no container runtime CLI can be found for an auto-detected runtime.
+//
+//nolint:grouper // false positive
+var ErrNoRuntimeDetected = errors.New("no container runtime found, install docker, nerdctl or podman")
+
+// ErrNoClusterNodes is returned when no containers exist for a cluster.
+//
+//nolint:grouper // false positive
+var ErrNoClusterNodes = errors.New("cluster has no nodes")
+
+// ErrNoMatchingNodes is returned when no node of a cluster matches the requested role or name.
+//
+//nolint:grouper // false positive
+var ErrNoMatchingNodes = errors.New("no matching nodes")
+
+// nodeExecResult is the outcome of a command run inside a node container.
+type nodeExecResult struct {
+	node     string
+	stdout   string
+	stderr   string
+	exitCode int
+}
+
+// nodeStartOrder ranks node roles for startup: the control plane comes first,
+// then the load balancer in front of it, then the workers.
+//
+//nolint:gochecknoglobals // lookup table
+var nodeStartOrder = map[string]int{
+	constants.ControlPlaneNodeRoleValue:         0,
+	constants.ExternalLoadBalancerNodeRoleValue: 1,
+	constants.WorkerNodeRoleValue:               2,
+}
+
+// runtimeBinary returns the CLI of the named runtime. An empty name is auto-detected
+// in the same order kind uses: docker, nerdctl, then podman.
+func runtimeBinary(name string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+
+	for _, candidate := range []string{providerDocker, providerNerdctl, providerPodman} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", ErrNoRuntimeDetected
+}
+
+// allNodes lists every container of a cluster, including the external load balancer of HA clusters.
+func (r *kindRuntime) allNodes(clusterName string) ([]nodes.Node, error) {
+	var nodeList []nodes.Node
+
+	err := r.run(func(provider *cluster.Provider) error {
+		var listErr error
+
+		nodeList, listErr = provider.ListNodes(clusterName)
+
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes of cluster %s: %w", clusterName, err)
+	}
+
+	return nodeList, nil
+}
+
+// containerCommand runs the runtime CLI with args while the runtime environment is applied.
+func (r *kindRuntime) containerCommand(ctx context.Context, args ...string) (string, error) {
+	binary, err := runtimeBinary(r.name)
+	if err != nil {
+		return "", err
+	}
+
+	var output []byte
+
+	err = withRuntimeEnv(r.env, func() error {
+		var cmdErr error
+
+		output, cmdErr = exec.CommandContext(ctx, binary, args...).CombinedOutput()
+
+		return cmdErr
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w: %s", binary, args[0], err, strings.TrimSpace(string(output)))
+	}
+
+	return string(output), nil
+}
+
+// runningNodes counts the running containers of the cluster and the total number of containers.
+func (r *kindRuntime) runningNodes(ctx context.Context, clusterName string) (int, int, error) {
+	nodeList, err := r.allNodes(clusterName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(nodeList) == 0 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrNoClusterNodes, clusterName)
+	}
+
+	args := []string{"inspect", "--format", "{{.State.Running}}"}
+	for _, node := range nodeList {
+		args = append(args, node.String())
+	}
+
+	output, err := r.containerCommand(ctx, args...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	running := 0
+
+	for _, state := range strings.Fields(output) {
+		if state == "true" {
+			running++
+		}
+	}
+
+	return running, len(nodeList), nil
+}
+
+// stopCluster stops every container of the cluster, including the external load balancer.
+func (r *kindRuntime) stopCluster(ctx context.Context, clusterName string) error {
+	nodeList, err := r.allNodes(clusterName)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"stop"}
+	for _, node := range nodeList {
+		args = append(args, node.String())
+	}
+
+	_, err = r.containerCommand(ctx, args...)
+
+	return err
+}
+
+// startCluster starts the containers of the cluster one by one in nodeStartOrder.
+func (r *kindRuntime) startCluster(ctx context.Context, clusterName string) error {
+	nodeList, err := r.allNodes(clusterName)
+	if err != nil {
+		return err
+	}
+
+	nodeList, err = r.sortNodesForStart(nodeList)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodeList {
+		_, err = r.containerCommand(ctx, "start", node.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nodeRole returns the role of a node. The role is read from the container labels,
+// so the lookup runs with the runtime environment applied.
+func (r *kindRuntime) nodeRole(node nodes.Node) (string, error) {
+	var role string
+
+	err := withRuntimeEnv(r.env, func() error {
+		var roleErr error
+
+		role, roleErr = node.Role()
+
+		return roleErr
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get role of node %s: %w", node.String(), err)
+	}
+
+	return role, nil
+}
+
+// sortNodesForStart orders nodes by role in nodeStartOrder, then by name.
+func (r *kindRuntime) sortNodesForStart(nodeList []nodes.Node) ([]nodes.Node, error) {
+	ranks := make(map[string]int, len(nodeList))
+
+	for _, node := range nodeList {
+		role, err := r.nodeRole(node)
+		if err != nil {
+			return nil, err
+		}
+
+		rank, known := nodeStartOrder[role]
+		if !known {
+			rank = len(nodeStartOrder)
+		}
+
+		ranks[node.String()] = rank
+	}
+
+	sorted := slices.Clone(nodeList)
+	slices.SortStableFunc(sorted, func(a, b nodes.Node) int {
+		if rankDiff := ranks[a.String()] - ranks[b.String()]; rankDiff != 0 {
+			return rankDiff
+		}
+
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return sorted, nil
+}
+
+// clusterExists reports whether the runtime holds a cluster with this name.
+func (r *kindRuntime) clusterExists(clusterName string) (bool, error) {
+	var clusters []string
+
+	err := r.run(func(provider *cluster.Provider) error {
+		var listErr error
+
+		clusters, listErr = provider.List()
+
+		return listErr
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	return slices.Contains(clusters, clusterName), nil
+}
+
+// internalNodes lists the Kubernetes node containers of a cluster,
+// excluding the external load balancer of HA clusters.
+func (r *kindRuntime) internalNodes(clusterName string) ([]nodes.Node, error) {
+	var nodeList []nodes.Node
+
+	err := r.run(func(provider *cluster.Provider) error {
+		var listErr error
+
+		nodeList, listErr = provider.ListInternalNodes(clusterName)
+
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes of cluster %s: %w", clusterName, err)
+	}
+
+	return nodeList, nil
+}
+
+// writeNodeFiles writes the files, keyed by destination path, into every node.
+func (r *kindRuntime) writeNodeFiles(nodeList []nodes.Node, files map[string]string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	return withRuntimeEnv(r.env, func() error {
+		for _, node := range nodeList {
+			for _, dest := range slices.Sorted(maps.Keys(files)) {
+				err := nodeutils.WriteFile(node, dest, files[dest])
+				if err != nil {
+					return fmt.Errorf("failed to write %s to node %s: %w", dest, node.String(), err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// selectNodes returns the nodes with the given role, or the node with the given name.
+// Both empty selects every node.
+func (r *kindRuntime) selectNodes(nodeList []nodes.Node, role, nodeName string) ([]nodes.Node, error) {
+	var selected []nodes.Node
+
+	for _, node := range nodeList {
+		if nodeName != "" && node.String() != nodeName {
+			continue
+		}
+
+		if role != "" {
+			nodeRole, err := r.nodeRole(node)
+			if err != nil {
+				return nil, err
+			}
+
+			if nodeRole != role {
+				continue
+			}
+		}
+
+		selected = append(selected, node)
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("%w: role %q, node name %q", ErrNoMatchingNodes, role, nodeName)
+	}
+
+	return r.sortNodesForStart(selected)
+}
+
+// selectClusterNodes lists the containers of a cluster and returns those matching selectNodes.
+func (r *kindRuntime) selectClusterNodes(clusterName, role, nodeName string) ([]nodes.Node, error) {
+	nodeList, err := r.allNodes(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.selectNodes(nodeList, role, nodeName)
+}
+
+// execInNodes runs a command inside each node in turn and captures its output.
+// A non-zero exit code is reported in the result, other failures are returned as errors.
+func (r *kindRuntime) execInNodes(
+	ctx context.Context,
+	nodeList []nodes.Node,
+	command []string,
+) ([]nodeExecResult, error) {
+	results := make([]nodeExecResult, 0, len(nodeList))
+
+	err := withRuntimeEnv(r.env, func() error {
+		for _, node := range nodeList {
+			result, err := execInNode(ctx, node, command)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// execInNode runs a command inside a node container.
+func execInNode(ctx context.Context, node nodes.Node, command []string) (nodeExecResult, error) {
+	var stdout, stderr bytes.Buffer
+
+	result := nodeExecResult{node: node.String()}
+
+	err := node.CommandContext(ctx, command[0], command[1:]...).
+		SetStdout(&stdout).
+		SetStderr(&stderr).
+		Run()
+
+	result.stdout = stdout.String()
+	result.stderr = stderr.String()
+
+	if err == nil {
+		return result, nil
+	}
+
+	var exitErr *exec.ExitError
+
+	runErr := kindexec.RunErrorForError(err)
+	if runErr != nil && errors.As(runErr.Inner, &exitErr) {
+		result.exitCode = exitErr.ExitCode()
+
+		return result, nil
+	}
+
+	return result, fmt.Errorf("failed to run %s in node %s: %w", command[0], node.String(), err)
+}
+
+// execInNodesChecked runs a command inside the nodes and fails on any non-zero exit code.
+func (r *kindRuntime) execInNodesChecked(ctx context.Context, nodeList []nodes.Node, command ...string) error {
+	results, err := r.execInNodes(ctx, nodeList, command)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.exitCode != 0 {
+			return fmt.Errorf("%s exited with code %d on node %s: %s",
+				command[0], result.exitCode, result.node, strings.TrimSpace(result.stderr))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	kindexec "sigs.k8s.io/kind/pkg/exec"
+)
+
+var errTestRole = errors.New("role lookup failed")
+
+// testNode is a nodes.Node stub that only knows its name and role.
+type testNode struct {
+	nodes.Node
+
+	roleErr error
+	name    string
+	role    string
+}
+
+func (n testNode) String() string { return n.name }
+
+func (n testNode) Role() (string, error) { return n.role, n.roleErr }
+
+// localNode is a node stub that runs its commands on the host.
+type localNode struct {
+	testNode
+}
+
+func (localNode) CommandContext(ctx context.Context, command string, args ...string) kindexec.Cmd {
+	return kindexec.CommandContext(ctx, command, args...)
+}
+
+// envRoleNode is a node stub whose role is read from the process environment, like the
+// container labels kind reads through the runtime CLI.
+type envRoleNode struct {
+	testNode
+}
+
+func (envRoleNode) Role() (string, error) { return os.Getenv(testEnvVar), nil }
+
+func TestNodeRole(t *testing.T) {
+	runtime := &kindRuntime{env: map[string]string{testEnvVar: "worker"}}
+
+	role, err := runtime.nodeRole(envRoleNode{testNode{name: "test-worker"}})
+	require.NoError(t, err)
+	assert.Equal(t, "worker", role, "the role is looked up with the runtime environment applied")
+
+	_, err = runtime.nodeRole(testNode{name: "broken", roleErr: errTestRole})
+	require.ErrorIs(t, err, errTestRole)
+}
+
+func TestSortNodesForStart(t *testing.T) {
+	nodeList := []nodes.Node{
+		testNode{name: "test-worker2", role: "worker"},
+		testNode{name: "test-external-load-balancer", role: "external-load-balancer"},
+		testNode{name: "test-worker", role: "worker"},
+		testNode{name: "test-control-plane2", role: "control-plane"},
+		testNode{name: "test-control-plane", role: "control-plane"},
+	}
+
+	runtime := &kindRuntime{env: map[string]string{}}
+
+	sorted, err := runtime.sortNodesForStart(nodeList)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(sorted))
+	for _, node := range sorted {
+		names = append(names, node.String())
+	}
+
+	assert.Equal(t, []string{
+		"test-control-plane",
+		"test-control-plane2",
+		"test-external-load-balancer",
+		"test-worker",
+		"test-worker2",
+	}, names)
+
+	_, err = runtime.sortNodesForStart([]nodes.Node{testNode{name: "broken", roleErr: errTestRole}})
+	require.ErrorIs(t, err, errTestRole)
+}
+
+func TestRuntimeBinary(t *testing.T) {
+	binary, err := runtimeBinary(providerPodman)
+	require.NoError(t, err)
+	assert.Equal(t, providerPodman, binary, "an explicit runtime is used as is")
+
+	t.Setenv("PATH", t.TempDir())
+
+	_, err = runtimeBinary("")
+	require.ErrorIs(t, err, ErrNoRuntimeDetected)
+}
+
+func TestSelectNodes(t *testing.T) {
+	nodeList := []nodes.Node{
+		testNode{name: "test-worker2", role: "worker"},
+		testNode{name: "test-control-plane", role: "control-plane"},
+		testNode{name: "test-worker", role: "worker"},
+	}
+
+	names := func(selected []nodes.Node) []string {
+		result := make([]string, 0, len(selected))
+		for _, node := range selected {
+			result = append(result, node.String())
+		}
+
+		return result
+	}
+
+	runtime := &kindRuntime{env: map[string]string{}}
+
+	selected, err := runtime.selectNodes(nodeList, "worker", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-worker", "test-worker2"}, names(selected))
+
+	selected, err = runtime.selectNodes(nodeList, "", "test-worker2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-worker2"}, names(selected))
+
+	selected, err = runtime.selectNodes(nodeList, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-control-plane", "test-worker", "test-worker2"}, names(selected))
+
+	_, err = runtime.selectNodes(nodeList, "external-load-balancer", "")
+	require.ErrorIs(t, err, ErrNoMatchingNodes)
+}
+
+func TestExecInNode(t *testing.T) {
+	node := localNode{testNode{name: "test-worker"}}
+
+	result, err := execInNode(t.Context(), node, []string{"sh", "-c", "echo out; echo err >&2; exit 3"})
+	require.NoError(t, err, "a non-zero exit code is not an error")
+	assert.Equal(t, nodeExecResult{node: "test-worker", stdout: "out\n", stderr: "err\n", exitCode: 3}, result)
+
+	result, err = execInNode(t.Context(), node, []string{"true"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.exitCode)
+
+	_, err = execInNode(t.Context(), node, []string{"/nonexistent/command"})
+	require.Error(t, err, "commands that cannot start are errors")
+}
@@ -0,0 +1,310 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = YAMLPatchType{}
+	_ basetypes.StringValuableWithSemanticEquals = YAMLPatchValue{}
+	_ basetypes.StringTypable                    = TOMLPatchType{}
+	_ basetypes.StringValuableWithSemanticEquals = TOMLPatchValue{}
+)
+
+// YAMLPatchType is a string type holding (multi-document) YAML patches, such as kubeadm config patches.
+// YAMLPatchValue is a YAML patch value that is semantically equal to any YAML encoding of the same documents.
+// TOMLPatchType is a string type holding TOML patches, such as containerd config patches.
+// TOMLPatchValue is a TOML patch value that is semantically equal to any TOML encoding of the same tables.
+type (
+	YAMLPatchType struct {
+		basetypes.StringType
+	}
+
+	YAMLPatchValue struct {
+		basetypes.StringValue
+	}
+
+	TOMLPatchType struct {
+		basetypes.StringType
+	}
+
+	TOMLPatchValue struct {
+		basetypes.StringValue
+	}
+)
+
+// Equal returns true if the given type is a YAMLPatchType.
+func (t YAMLPatchType) Equal(o attr.Type) bool {
+	other, ok := o.(YAMLPatchType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// String returns a human readable name of the type.
+func (YAMLPatchType) String() string {
+	return "YAMLPatchType"
+}
+
+// ValueFromString wraps a StringValue into a YAMLPatchValue.
+//
+//nolint:ireturn // required by basetypes.StringTypable
+func (YAMLPatchType) ValueFromString(
+	_ context.Context,
+	in basetypes.StringValue,
+) (basetypes.StringValuable, diag.Diagnostics) {
+	return YAMLPatchValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a YAMLPatchValue.
+//
+//nolint:ireturn // required by attr.Type
+func (t YAMLPatchType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	stringValue, err := stringValueFromTerraform(ctx, t.StringType, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return YAMLPatchValue{StringValue: stringValue}, nil
+}
+
+// ValueType returns the value type of YAMLPatchType.
+//
+//nolint:ireturn // required by attr.Type
+func (YAMLPatchType) ValueType(_ context.Context) attr.Value {
+	return YAMLPatchValue{}
+}
+
+// Equal returns true if the given value is a YAMLPatchValue with the same string.
+func (v YAMLPatchValue) Equal(o attr.Value) bool {
+	other, ok := o.(YAMLPatchValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Type returns YAMLPatchType.
+//
+//nolint:ireturn // required by attr.Value
+func (YAMLPatchValue) Type(_ context.Context) attr.Type {
+	return YAMLPatchType{}
+}
+
+// StringSemanticEquals returns true if both values decode to the same YAML documents,
+// so re-indentation or trailing newlines do not show up as changes.
+func (v YAMLPatchValue) StringSemanticEquals(
+	_ context.Context,
+	newValuable basetypes.StringValuable,
+) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(YAMLPatchValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return yamlSemanticEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// Equal returns true if the given type is a TOMLPatchType.
+func (t TOMLPatchType) Equal(o attr.Type) bool {
+	other, ok := o.(TOMLPatchType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// String returns a human readable name of the type.
+func (TOMLPatchType) String() string {
+	return "TOMLPatchType"
+}
+
+// ValueFromString wraps a StringValue into a TOMLPatchValue.
+//
+//nolint:ireturn // required by basetypes.StringTypable
+func (TOMLPatchType) ValueFromString(
+	_ context.Context,
+	in basetypes.StringValue,
+) (basetypes.StringValuable, diag.Diagnostics) {
+	return TOMLPatchValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a TOMLPatchValue.
+//
+//nolint:ireturn // required by attr.Type
+func (t TOMLPatchType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	stringValue, err := stringValueFromTerraform(ctx, t.StringType, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return TOMLPatchValue{StringValue: stringValue}, nil
+}
+
+// ValueType returns the value type of TOMLPatchType.
+//
+//nolint:ireturn // required by attr.Type
+func (TOMLPatchType) ValueType(_ context.Context) attr.Value {
+	return TOMLPatchValue{}
+}
+
+// Equal returns true if the given value is a TOMLPatchValue with the same string.
+func (v TOMLPatchValue) Equal(o attr.Value) bool {
+	other, ok := o.(TOMLPatchValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Type returns TOMLPatchType.
+//
+//nolint:ireturn // required by attr.Value
+func (TOMLPatchValue) Type(_ context.Context) attr.Type {
+	return TOMLPatchType{}
+}
+
+// StringSemanticEquals returns true if both values normalize to the same TOML,
+// so formatting, key order and comments do not show up as changes.
+func (v TOMLPatchValue) StringSemanticEquals(
+	_ context.Context,
+	newValuable basetypes.StringValuable,
+) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TOMLPatchValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	return tomlSemanticEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// stringValueFromTerraform converts a Terraform value into a StringValue using the base string type.
+func stringValueFromTerraform(
+	ctx context.Context,
+	stringType basetypes.StringType,
+	in tftypes.Value,
+) (basetypes.StringValue, error) {
+	attrValue, err := stringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return basetypes.StringValue{}, fmt.Errorf("failed to convert string value: %w", err)
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return basetypes.StringValue{}, fmt.Errorf("unexpected value type %T", attrValue)
+	}
+
+	return stringValue, nil
+}
+
+// yamlSemanticEqual reports whether two YAML streams decode to the same documents.
+// Streams that fail to decode are only equal when they are identical strings.
+func yamlSemanticEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	aDocuments, aErr := decodeYAMLDocuments(a)
+	bDocuments, bErr := decodeYAMLDocuments(b)
+
+	if aErr != nil || bErr != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(aDocuments, bDocuments)
+}
+
+// decodeYAMLDocuments decodes every non-empty document of a YAML stream.
+func decodeYAMLDocuments(stream string) ([]any, error) {
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(strings.NewReader(stream)))
+
+	var documents []any
+
+	for {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read YAML document: %w", err)
+		}
+
+		var document any
+
+		err = yaml.Unmarshal(raw, &document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document: %w", err)
+		}
+
+		if document != nil {
+			documents = append(documents, document)
+		}
+	}
+}
+
+// tomlSemanticEqual reports whether two TOML documents normalize to the same output.
+// Documents that fail to parse are only equal when they are identical strings.
+func tomlSemanticEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	aNormalized, aErr := normalizeToml(a)
+	bNormalized, bErr := normalizeToml(b)
+
+	if aErr != nil || bErr != nil {
+		return false
+	}
+
+	return aNormalized == bNormalized
+}
@@ -0,0 +1,308 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKubeadmPatch is a kubeadm patch used in semantic equality tests.
+const testKubeadmPatch = `kind: InitConfiguration
+nodeRegistration:
+  kubeletExtraArgs:
+    node-labels: "ingress-ready=true"
+`
+
+// testContainerdPatch is a containerd patch used in plan tests.
+const testContainerdPatch = "[plugins.cri]\n  sandbox_image = \"test\"\n"
+
+func TestYAMLPatchValue_StringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		newValue string
+		expected bool
+	}{
+		{
+			name:     "identical",
+			newValue: testKubeadmPatch,
+			expected: true,
+		},
+		{
+			name: "re-indented with trailing newlines",
+			newValue: "kind: InitConfiguration\nnodeRegistration:\n    kubeletExtraArgs:\n" +
+				"        node-labels: ingress-ready=true\n\n\n",
+			expected: true,
+		},
+		{
+			name:     "leading document separator",
+			newValue: "---\n" + testKubeadmPatch,
+			expected: true,
+		},
+		{
+			name:     "changed value",
+			newValue: "kind: InitConfiguration\nnodeRegistration:\n  kubeletExtraArgs:\n    node-labels: \"ingress-ready=false\"\n",
+			expected: false,
+		},
+		{
+			name:     "additional document",
+			newValue: testKubeadmPatch + "---\nkind: JoinConfiguration\n",
+			expected: false,
+		},
+		{
+			name:     "invalid YAML",
+			newValue: "kind: [",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := YAMLPatchValue{StringValue: types.StringValue(testKubeadmPatch)}
+
+			equal, diags := prior.StringSemanticEquals(
+				t.Context(),
+				YAMLPatchValue{StringValue: types.StringValue(tt.newValue)},
+			)
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+}
+
+func TestTOMLPatchValue_StringSemanticEquals(t *testing.T) {
+	const patch = "[plugins.cri]\n  sandbox_image = \"test\"\n  max_conf_num = 1\n"
+
+	tests := []struct {
+		name     string
+		newValue string
+		expected bool
+	}{
+		{
+			name:     "identical",
+			newValue: patch,
+			expected: true,
+		},
+		{
+			name:     "reformatted and reordered",
+			newValue: "# comment\n[plugins.cri]\nmax_conf_num = 1\nsandbox_image = \"test\"",
+			expected: true,
+		},
+		{
+			name:     "changed value",
+			newValue: "[plugins.cri]\n  sandbox_image = \"other\"\n  max_conf_num = 1\n",
+			expected: false,
+		},
+		{
+			name:     "invalid TOML",
+			newValue: "[plugins.cri",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := TOMLPatchValue{StringValue: types.StringValue(patch)}
+
+			equal, diags := prior.StringSemanticEquals(
+				t.Context(),
+				TOMLPatchValue{StringValue: types.StringValue(tt.newValue)},
+			)
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+}
+
+func TestPatchValue_StringSemanticEquals_WrongType(t *testing.T) {
+	_, diags := YAMLPatchValue{StringValue: types.StringValue("a: 1")}.StringSemanticEquals(
+		t.Context(),
+		types.StringValue("a: 1"),
+	)
+	assert.True(t, diags.HasError(), "YAML patches should only compare with YAML patches")
+
+	_, diags = TOMLPatchValue{StringValue: types.StringValue("a = 1")}.StringSemanticEquals(
+		t.Context(),
+		types.StringValue("a = 1"),
+	)
+	assert.True(t, diags.HasError(), "TOML patches should only compare with TOML patches")
+}
+
+func TestPatchTypes_ValueFromTerraform(t *testing.T) {
+	in := tftypes.NewValue(tftypes.String, "a: 1")
+
+	yamlValue, err := YAMLPatchType{}.ValueFromTerraform(t.Context(), in)
+	require.NoError(t, err)
+	assert.Equal(t, YAMLPatchValue{StringValue: types.StringValue("a: 1")}, yamlValue)
+	assert.True(t, YAMLPatchType{}.Equal(yamlValue.Type(t.Context())))
+
+	tomlValue, err := TOMLPatchType{}.ValueFromTerraform(t.Context(), in)
+	require.NoError(t, err)
+	assert.Equal(t, TOMLPatchValue{StringValue: types.StringValue("a: 1")}, tomlValue)
+	assert.True(t, TOMLPatchType{}.Equal(tomlValue.Type(t.Context())))
+
+	assert.False(t, YAMLPatchType{}.Equal(TOMLPatchType{}), "patch types should be distinct")
+	assert.False(t, YAMLPatchType{}.Equal(basetypes.StringType{}))
+}
+
+func TestAttrValueToAny_PatchValues(t *testing.T) {
+	assert.Equal(t, "a: 1", attrValueToAny(YAMLPatchValue{StringValue: types.StringValue("a: 1")}))
+	assert.Equal(t, "a = 1", attrValueToAny(TOMLPatchValue{StringValue: types.StringValue("a = 1")}))
+	assert.Nil(t, attrValueToAny(YAMLPatchValue{StringValue: types.StringNull()}))
+}
+
+// planClusterPatches plans a kind_cluster whose state holds the prior patches and whose
+// configuration holds the configured ones, through the provider protocol server so that the
+// plan modifiers run exactly as they do under Terraform.
+func planClusterPatches(
+	t *testing.T,
+	priorPatches, configuredPatches map[string][]string,
+) (*tfprotov6.PlanResourceChangeResponse, tfsdk.Plan) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewClusterResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	// clusterValue builds a kind_cluster value with the patches in kind_config
+	clusterValue := func(patches map[string][]string, prior bool) *tfprotov6.DynamicValue {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		require.False(t, state.SetAttribute(ctx, path.Root("name"), "dev").HasError())
+
+		// node_image is configured so that it does not become unknown and force a replacement
+		require.False(t, state.SetAttribute(ctx, path.Root("node_image"), defaultNodeImage).HasError())
+
+		if prior {
+			require.False(t, state.SetAttribute(ctx, path.Root("id"), "dev").HasError())
+		}
+
+		kindConfig := path.Root("kind_config").AtListIndex(0)
+		require.False(t, state.SetAttribute(ctx, kindConfig.AtName("kind"), "Cluster").HasError())
+		require.False(t, state.SetAttribute(ctx, kindConfig.AtName("api_version"), "kind.x-k8s.io/v1alpha4").HasError())
+		require.False(t, state.SetAttribute(ctx,
+			kindConfig.AtName("containerd_config_patches"), patches["containerd"]).HasError())
+		require.False(t, state.SetAttribute(ctx,
+			kindConfig.AtName("node").AtListIndex(0).AtName("role"), "control-plane").HasError())
+		require.False(t, state.SetAttribute(ctx,
+			kindConfig.AtName("node").AtListIndex(0).AtName("kubeadm_config_patches"), patches["kubeadm"]).HasError())
+
+		value, err := tfprotov6.NewDynamicValue(schemaResp.Schema.Type().TerraformType(ctx), state.Raw)
+		require.NoError(t, err)
+
+		return &value
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	require.NoError(t, err)
+
+	_, err = server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "kind_cluster",
+		PriorState:       clusterValue(priorPatches, true),
+		ProposedNewState: clusterValue(configuredPatches, true),
+		Config:           clusterValue(configuredPatches, false),
+	})
+	require.NoError(t, err)
+
+	for _, diagnostic := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, diagnostic.Severity, diagnostic.Detail)
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+
+	return resp, tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned}
+}
+
+func TestPatchLists_Plan(t *testing.T) {
+	prior := map[string][]string{
+		"containerd": {testContainerdPatch},
+		"kubeadm":    {testKubeadmPatch},
+	}
+
+	tests := []struct {
+		configured map[string][]string
+		name       string
+		replace    bool
+	}{
+		{
+			name: "reformatted",
+			configured: map[string][]string{
+				"containerd": {"\n" + strings.ReplaceAll(testContainerdPatch, " = ", "=") + "\n"},
+				"kubeadm":    {"---\n" + strings.ReplaceAll(testKubeadmPatch, "  ", "    ")},
+			},
+		},
+		{
+			name: "changed",
+			configured: map[string][]string{
+				"containerd": {testContainerdPatch},
+				"kubeadm":    {strings.ReplaceAll(testKubeadmPatch, "ingress-ready=true", "ingress-ready=false")},
+			},
+			replace: true,
+		},
+		{
+			name: "added",
+			configured: map[string][]string{
+				"containerd": {testContainerdPatch, testContainerdPatch},
+				"kubeadm":    {testKubeadmPatch},
+			},
+			replace: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, plan := planClusterPatches(t, prior, tt.configured)
+
+			assert.Equal(t, tt.replace, len(resp.RequiresReplace) > 0, "requires replace: %v", resp.RequiresReplace)
+
+			if tt.replace {
+				return
+			}
+
+			var containerdPatches, kubeadmPatches []string
+
+			kindConfig := path.Root("kind_config").AtListIndex(0)
+			require.False(t, plan.GetAttribute(t.Context(),
+				kindConfig.AtName("containerd_config_patches"), &containerdPatches).HasError())
+			require.False(t, plan.GetAttribute(t.Context(),
+				kindConfig.AtName("node").AtListIndex(0).AtName("kubeadm_config_patches"), &kubeadmPatches).HasError())
+
+			assert.Equal(t, prior["containerd"], containerdPatches)
+			assert.Equal(t, prior["kubeadm"], kubeadmPatches)
+		})
+	}
+}
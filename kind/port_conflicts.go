@@ -0,0 +1,436 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const (
+	// kindClusterLabel is the container label holding the cluster name of a kind node.
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+	// defaultPortMappingAddress is the address kind publishes extra port mappings on when none is set.
+	defaultPortMappingAddress = "0.0.0.0"
+	// procRoot is the procfs mount read to name the process holding a port.
+	procRoot = "/proc"
+	// tcpListenState is the state of listening sockets in /proc/net/tcp.
+	tcpListenState = "0A"
+)
+
+// hostPortBinding is a fixed host port a cluster declares, with the attribute declaring it.
+type hostPortBinding struct {
+	Attribute     path.Path
+	Protocol      string
+	ListenAddress string
+	HostPort      int
+}
+
+// clusterPortBinding is a host port published by a node of an existing kind cluster.
+type clusterPortBinding struct {
+	Cluster       string
+	Node          string
+	Protocol      string
+	ListenAddress string
+	HostPort      int
+	Running       bool
+}
+
+// runtimePortBinding is an entry of the port maps reported by the runtime inspect command.
+type runtimePortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+// String returns the binding in the port/protocol on address notation used in diagnostics.
+func (b hostPortBinding) String() string {
+	return fmt.Sprintf("host port %d/%s on %s", b.HostPort, b.Protocol, b.ListenAddress)
+}
+
+// declaredHostPorts returns the fixed host ports of the kind configuration: the API server
+// port and the extra port mappings with a host_port. Runtime-picked ports cannot conflict.
+func declaredHostPorts(kindConfig *v1alpha4.Cluster) []hostPortBinding {
+	if kindConfig == nil {
+		return nil
+	}
+
+	configPath := path.Root("kind_config").AtListIndex(0)
+
+	var bindings []hostPortBinding
+
+	if kindConfig.Networking.APIServerPort > 0 {
+		apiServerAddress := defaultAPIServerAddress
+		if kindConfig.Networking.IPFamily == v1alpha4.IPv6Family {
+			apiServerAddress = defaultAPIServerAddressIPv6
+		}
+
+		bindings = append(bindings, hostPortBinding{
+			Attribute:     configPath.AtName("networking").AtName("api_server_port"),
+			Protocol:      string(v1alpha4.PortMappingProtocolTCP),
+			ListenAddress: cmp.Or(kindConfig.Networking.APIServerAddress, apiServerAddress),
+			HostPort:      int(kindConfig.Networking.APIServerPort),
+		})
+	}
+
+	for i, node := range kindConfig.Nodes {
+		for j, mapping := range node.ExtraPortMappings {
+			if mapping.HostPort <= 0 {
+				continue
+			}
+
+			bindings = append(bindings, hostPortBinding{
+				Attribute: configPath.AtName("node").AtListIndex(i).
+					AtName("extra_port_mappings").AtListIndex(j).AtName("host_port"),
+				Protocol:      strings.ToUpper(cmp.Or(string(mapping.Protocol), string(v1alpha4.PortMappingProtocolTCP))),
+				ListenAddress: cmp.Or(mapping.ListenAddress, defaultPortMappingAddress),
+				HostPort:      int(mapping.HostPort),
+			})
+		}
+	}
+
+	return bindings
+}
+
+// listenAddressesOverlap reports whether two listen addresses can conflict: they are equal,
+// or one of them is a wildcard covering the other. The IPv6 wildcard also covers IPv4.
+func listenAddressesOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	addrA, addrB = addrA.Unmap(), addrB.Unmap()
+
+	switch {
+	case addrA == addrB:
+		return true
+	case addrA.IsUnspecified() && (addrA.Is6() || addrB.Is4()):
+		return true
+	case addrB.IsUnspecified() && (addrB.Is6() || addrA.Is4()):
+		return true
+	default:
+		return false
+	}
+}
+
+// conflicts reports whether the cluster binding holds the same host port as the declared one.
+func (c clusterPortBinding) conflicts(binding hostPortBinding) bool {
+	return c.HostPort == binding.HostPort && c.Protocol == binding.Protocol &&
+		listenAddressesOverlap(c.ListenAddress, binding.ListenAddress)
+}
+
+// kindClusterPorts returns the host ports published by the nodes of every kind cluster of the
+// runtime. Running nodes report their actual bindings, stopped nodes the bindings they
+// will request when they start again.
+func (r *kindRuntime) kindClusterPorts(ctx context.Context) ([]clusterPortBinding, error) {
+	output, err := r.containerCommand(ctx,
+		"ps", "--all",
+		"--filter", "label="+kindClusterLabel,
+		"--format", "{{.Names}}",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := strings.Fields(output)
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{
+		"inspect", "--format",
+		`{{.Name}}{{"\t"}}{{index .Config.Labels "` + kindClusterLabel + `"}}{{"\t"}}{{.State.Running}}` +
+			`{{"\t"}}{{json .NetworkSettings.Ports}}{{"\t"}}{{json .HostConfig.PortBindings}}`,
+	}, containers...)
+
+	output, err = r.containerCommand(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClusterPortBindings(output), nil
+}
+
+// parseClusterPortBindings parses the output of the inspect command of kindClusterPorts,
+// one tab separated line per node: name, cluster, running, and the JSON port maps of the
+// running container and of its configuration.
+func parseClusterPortBindings(output string) []clusterPortBinding {
+	var bindings []clusterPortBinding
+
+	for line := range strings.Lines(output) {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 5 { //nolint:mnd // name, cluster, running and two port maps
+			continue
+		}
+
+		running := fields[2] == "true"
+
+		portMap := fields[4]
+		if running {
+			portMap = fields[3]
+		}
+
+		var ports map[string][]runtimePortBinding
+
+		if err := json.Unmarshal([]byte(portMap), &ports); err != nil {
+			continue
+		}
+
+		for containerPort, hostBindings := range ports {
+			_, protocol, _ := strings.Cut(containerPort, "/")
+
+			for _, hostBinding := range hostBindings {
+				hostPort, err := strconv.Atoi(hostBinding.HostPort)
+				if err != nil || hostPort <= 0 {
+					// Ports the runtime picks on start are not known ahead
+					continue
+				}
+
+				binding := clusterPortBinding{
+					Cluster:       fields[1],
+					Node:          strings.TrimPrefix(fields[0], "/"),
+					Protocol:      strings.ToUpper(cmp.Or(protocol, "tcp")),
+					ListenAddress: cmp.Or(hostBinding.HostIP, defaultPortMappingAddress),
+					HostPort:      hostPort,
+					Running:       running,
+				}
+
+				if !slices.Contains(bindings, binding) {
+					bindings = append(bindings, binding)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(bindings, func(a, b clusterPortBinding) int {
+		return cmp.Or(
+			cmp.Compare(a.Node, b.Node),
+			cmp.Compare(a.HostPort, b.HostPort),
+			cmp.Compare(a.Protocol, b.Protocol),
+			cmp.Compare(a.ListenAddress, b.ListenAddress),
+		)
+	})
+
+	return bindings
+}
+
+// probeHostPort tries to bind the port on the local host. It returns the bind error when
+// the port is taken or the listen address does not exist on the host, nil otherwise.
+// Other failures, such as missing privileges for low ports, do not keep the runtime from
+// binding the port and are ignored.
+func probeHostPort(binding hostPortBinding) error {
+	address := net.JoinHostPort(binding.ListenAddress, strconv.Itoa(binding.HostPort))
+
+	var err error
+
+	switch binding.Protocol {
+	case string(v1alpha4.PortMappingProtocolTCP):
+		var listener net.Listener
+
+		listener, err = net.Listen("tcp", address)
+		if err == nil {
+			_ = listener.Close()
+		}
+	case string(v1alpha4.PortMappingProtocolUDP):
+		var conn net.PacketConn
+
+		conn, err = net.ListenPacket("udp", address)
+		if err == nil {
+			_ = conn.Close()
+		}
+	default:
+		// SCTP cannot be probed from Go without cgo
+		return nil
+	}
+
+	if errors.Is(err, syscall.EADDRINUSE) || errors.Is(err, syscall.EADDRNOTAVAIL) {
+		return err
+	}
+
+	return nil
+}
+
+// portOwner names the local process holding the port, as "name (pid N)", from the procfs
+// mounted at root. It returns an empty string when the owner cannot be found, which is
+// the case outside of Linux and for processes of other users.
+func portOwner(root, protocol string, port int) string {
+	var tables []string
+
+	switch protocol {
+	case string(v1alpha4.PortMappingProtocolTCP):
+		tables = []string{"tcp", "tcp6"}
+	case string(v1alpha4.PortMappingProtocolUDP):
+		tables = []string{"udp", "udp6"}
+	default:
+		return ""
+	}
+
+	var inodes []string
+
+	for _, table := range tables {
+		content, err := os.ReadFile(filepath.Join(root, "net", table))
+		if err != nil {
+			continue
+		}
+
+		inodes = append(inodes, socketInodes(string(content), port, protocol == string(v1alpha4.PortMappingProtocolTCP))...)
+	}
+
+	if len(inodes) == 0 {
+		return ""
+	}
+
+	fds, _ := filepath.Glob(filepath.Join(root, "[0-9]*", "fd", "*"))
+
+	for _, fd := range fds {
+		target, err := os.Readlink(fd)
+		if err != nil || !slices.Contains(inodes, strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]")) {
+			continue
+		}
+
+		processDir := filepath.Dir(filepath.Dir(fd))
+
+		comm, err := os.ReadFile(filepath.Join(processDir, "comm"))
+		if err != nil {
+			continue
+		}
+
+		return fmt.Sprintf("%s (pid %s)", strings.TrimSpace(string(comm)), filepath.Base(processDir))
+	}
+
+	return ""
+}
+
+// socketInodes returns the inodes of the sockets bound to the port in a /proc/net socket
+// table. TCP sockets only count while listening.
+func socketInodes(table string, port int, listening bool) []string {
+	var inodes []string
+
+	for line := range strings.Lines(table) {
+		fields := strings.Fields(line)
+		if len(fields) < 10 { //nolint:mnd // inode is the tenth column
+			continue
+		}
+
+		_, localPort, found := strings.Cut(fields[1], ":")
+		if !found {
+			continue
+		}
+
+		value, err := strconv.ParseUint(localPort, 16, 16)
+		if err != nil || int(value) != port || (listening && fields[3] != tcpListenState) {
+			continue
+		}
+
+		if fields[9] != "0" {
+			inodes = append(inodes, fields[9])
+		}
+	}
+
+	return inodes
+}
+
+// reportHostPortConflicts reports the declared host ports that another kind cluster already
+// publishes and, for local runtimes, the ones that cannot be bound on the host.
+// Running clusters and local processes holding a port are errors, stopped clusters
+// are warnings since the port is only taken once they start again.
+func reportHostPortConflicts(
+	bindings []hostPortBinding,
+	clusterName string,
+	others []clusterPortBinding,
+	probe func(hostPortBinding) error,
+	diags *diag.Diagnostics,
+) {
+	for _, binding := range bindings {
+		conflicting := false
+
+		for _, other := range others {
+			if other.Cluster == clusterName || !other.conflicts(binding) {
+				continue
+			}
+
+			conflicting = true
+
+			if other.Running {
+				diags.AddAttributeError(
+					binding.Attribute,
+					"Host port already in use",
+					fmt.Sprintf("%s is already published by node %s of kind cluster %s. "+
+						"Pick another host_port, or set it to 0 to let the runtime pick a free port.",
+						binding, other.Node, other.Cluster),
+				)
+			} else {
+				diags.AddAttributeWarning(
+					binding.Attribute,
+					"Host port reserved by a stopped cluster",
+					fmt.Sprintf("%s is also published by node %s of the stopped kind cluster %s. "+
+						"Whichever cluster starts second will fail to bind it.",
+						binding, other.Node, other.Cluster),
+				)
+			}
+
+			break
+		}
+
+		if conflicting || probe == nil {
+			continue
+		}
+
+		err := probe(binding)
+
+		switch {
+		case err == nil:
+		case errors.Is(err, syscall.EADDRNOTAVAIL):
+			diags.AddAttributeWarning(
+				binding.Attribute,
+				"Listen address not available",
+				fmt.Sprintf("%s cannot be bound: %s is not an address of this host.", binding, binding.ListenAddress),
+			)
+		default:
+			owner := cmp.Or(portOwner(procRoot, binding.Protocol, binding.HostPort), "another process")
+
+			diags.AddAttributeError(
+				binding.Attribute,
+				"Host port already in use",
+				fmt.Sprintf("%s is in use by %s. "+
+					"Stop it, pick another host_port, or set it to 0 to let the runtime pick a free port.",
+					binding, owner),
+			)
+		}
+	}
+}
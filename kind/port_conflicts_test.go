@@ -0,0 +1,287 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestDeclaredHostPorts(t *testing.T) {
+	configPath := path.Root("kind_config").AtListIndex(0)
+
+	tests := []struct {
+		config   *v1alpha4.Cluster
+		name     string
+		expected []hostPortBinding
+	}{
+		{name: "no config"},
+		{
+			name: "api server and port mappings",
+			config: &v1alpha4.Cluster{
+				Networking: v1alpha4.Networking{APIServerPort: 6443},
+				Nodes: []v1alpha4.Node{
+					{ExtraPortMappings: []v1alpha4.PortMapping{
+						{ContainerPort: 80, HostPort: 8080},
+						{ContainerPort: 53, HostPort: 5353, Protocol: v1alpha4.PortMappingProtocolUDP, ListenAddress: "127.0.0.1"},
+						{ContainerPort: 443, HostPort: runtimePickedHostPort},
+					}},
+				},
+			},
+			expected: []hostPortBinding{
+				{
+					Attribute:     configPath.AtName("networking").AtName("api_server_port"),
+					Protocol:      "TCP",
+					ListenAddress: "127.0.0.1",
+					HostPort:      6443,
+				},
+				{
+					Attribute:     configPath.AtName("node").AtListIndex(0).AtName("extra_port_mappings").AtListIndex(0).AtName("host_port"),
+					Protocol:      "TCP",
+					ListenAddress: "0.0.0.0",
+					HostPort:      8080,
+				},
+				{
+					Attribute:     configPath.AtName("node").AtListIndex(0).AtName("extra_port_mappings").AtListIndex(1).AtName("host_port"),
+					Protocol:      "UDP",
+					ListenAddress: "127.0.0.1",
+					HostPort:      5353,
+				},
+			},
+		},
+		{
+			name: "ipv6 api server",
+			config: &v1alpha4.Cluster{
+				Networking: v1alpha4.Networking{APIServerPort: 6443, IPFamily: v1alpha4.IPv6Family},
+			},
+			expected: []hostPortBinding{{
+				Attribute:     configPath.AtName("networking").AtName("api_server_port"),
+				Protocol:      "TCP",
+				ListenAddress: "::1",
+				HostPort:      6443,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, declaredHostPorts(tt.config))
+		})
+	}
+}
+
+func TestListenAddressesOverlap(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "127.0.0.1", b: "127.0.0.1", expected: true},
+		{a: "0.0.0.0", b: "127.0.0.1", expected: true},
+		{a: "127.0.0.1", b: "0.0.0.0", expected: true},
+		{a: "::", b: "127.0.0.1", expected: true},
+		{a: "::", b: "::1", expected: true},
+		{a: "0.0.0.0", b: "::1", expected: false},
+		{a: "127.0.0.1", b: "127.0.0.2", expected: false},
+		{a: "localhost", b: "127.0.0.1", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, listenAddressesOverlap(tt.a, tt.b))
+		})
+	}
+}
+
+func TestParseClusterPortBindings(t *testing.T) {
+	output := "/dev-control-plane\tdev\ttrue\t" +
+		`{"6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"41235"}],"80/tcp":[{"HostIp":"0.0.0.0","HostPort":"80"},{"HostIp":"::","HostPort":"80"}]}` +
+		"\t" + `{"6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"41235"}],"80/tcp":[{"HostIp":"","HostPort":"80"}]}` + "\n" +
+		"/ci-control-plane\tci\tfalse\t{}\t" +
+		`{"6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"36000"}],"53/udp":[{"HostIp":"","HostPort":""}]}` + "\n" +
+		"garbage\n"
+
+	assert.Equal(t, []clusterPortBinding{
+		{Cluster: "ci", Node: "ci-control-plane", Protocol: "TCP", ListenAddress: "127.0.0.1", HostPort: 36000},
+		{Cluster: "dev", Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "0.0.0.0", HostPort: 80, Running: true},
+		{Cluster: "dev", Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "::", HostPort: 80, Running: true},
+		{Cluster: "dev", Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "127.0.0.1", HostPort: 41235, Running: true},
+	}, parseClusterPortBindings(output))
+}
+
+func TestKindClusterPorts(t *testing.T) {
+	runtime, calls := fakeRuntimeCLI(t, map[string]string{
+		"ps --all":         "dev-control-plane\n",
+		"inspect --format": "/dev-control-plane\tdev\ttrue\t" + `{"80/tcp":[{"HostIp":"0.0.0.0","HostPort":"80"}]}` + "\t{}\n",
+	})
+
+	ports, err := runtime.kindClusterPorts(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []clusterPortBinding{
+		{Cluster: "dev", Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "0.0.0.0", HostPort: 80, Running: true},
+	}, ports)
+	assert.Len(t, calls(), 2)
+}
+
+func TestSocketInodes(t *testing.T) {
+	table := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 4242 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:1F90 0100007F:9C40 01 00000000:00000000 00:00000000 00000000  1000        0 4343 1 0000000000000000 20 4 30 10 -1
+   2: 00000000:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 4444 1 0000000000000000 100 0 0 10 0
+`
+
+	assert.Equal(t, []string{"4242"}, socketInodes(table, 8080, true))
+	assert.Equal(t, []string{"4242", "4343"}, socketInodes(table, 8080, false))
+	assert.Empty(t, socketInodes(table, 9090, true))
+}
+
+func TestPortOwner(t *testing.T) {
+	root := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "net"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "net", "tcp"), []byte(
+		"  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"+
+			"   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 4242 1\n",
+	), 0o600))
+
+	for pid, socket := range map[string]string{"100": "socket:[1]", "200": "socket:[4242]"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, pid, "fd"), 0o755))
+		require.NoError(t, os.Symlink(socket, filepath.Join(root, pid, "fd", "3")))
+		require.NoError(t, os.WriteFile(filepath.Join(root, pid, "comm"), []byte("proc"+pid+"\n"), 0o600))
+	}
+
+	assert.Equal(t, "proc200 (pid 200)", portOwner(root, "TCP", 8080))
+	assert.Empty(t, portOwner(root, "TCP", 9090))
+	assert.Empty(t, portOwner(root, "UDP", 8080))
+	assert.Empty(t, portOwner(root, "SCTP", 8080))
+}
+
+func TestProbeHostPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	assert.ErrorIs(t, probeHostPort(hostPortBinding{Protocol: "TCP", ListenAddress: "127.0.0.1", HostPort: port}), syscall.EADDRINUSE)
+	assert.NoError(t, probeHostPort(hostPortBinding{Protocol: "SCTP", ListenAddress: "127.0.0.1", HostPort: port}))
+
+	require.NoError(t, listener.Close())
+	assert.NoError(t, probeHostPort(hostPortBinding{Protocol: "TCP", ListenAddress: "127.0.0.1", HostPort: port}))
+}
+
+func TestReportHostPortConflicts(t *testing.T) {
+	apiServer := hostPortBinding{
+		Attribute:     path.Root("kind_config").AtListIndex(0).AtName("networking").AtName("api_server_port"),
+		Protocol:      "TCP",
+		ListenAddress: "127.0.0.1",
+		HostPort:      47613,
+	}
+	others := []clusterPortBinding{
+		{Cluster: "dev", Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "0.0.0.0", HostPort: 47613, Running: true},
+		{Cluster: "ci", Node: "ci-control-plane", Protocol: "TCP", ListenAddress: "127.0.0.1", HostPort: 47613},
+	}
+	inUse := func(hostPortBinding) error { return syscall.EADDRINUSE }
+
+	tests := []struct {
+		probe    func(hostPortBinding) error
+		name     string
+		cluster  string
+		others   []clusterPortBinding
+		errors   []string
+		warnings []string
+	}{
+		{
+			name:    "free",
+			cluster: "new",
+			probe:   func(hostPortBinding) error { return nil },
+		},
+		{
+			name:    "running cluster",
+			cluster: "new",
+			others:  others,
+			probe:   inUse,
+			errors: []string{
+				"host port 47613/TCP on 127.0.0.1 is already published by node dev-control-plane of kind cluster dev. " +
+					"Pick another host_port, or set it to 0 to let the runtime pick a free port.",
+			},
+		},
+		{
+			name:    "stopped cluster",
+			cluster: "dev",
+			others:  others,
+			probe:   inUse,
+			warnings: []string{
+				"host port 47613/TCP on 127.0.0.1 is also published by node ci-control-plane of the stopped kind cluster ci. " +
+					"Whichever cluster starts second will fail to bind it.",
+			},
+		},
+		{
+			name:    "local process",
+			cluster: "new",
+			probe:   inUse,
+			errors: []string{
+				"host port 47613/TCP on 127.0.0.1 is in use by another process. " +
+					"Stop it, pick another host_port, or set it to 0 to let the runtime pick a free port.",
+			},
+		},
+		{
+			name:    "unknown listen address",
+			cluster: "new",
+			probe:   func(hostPortBinding) error { return syscall.EADDRNOTAVAIL },
+			warnings: []string{
+				"host port 47613/TCP on 127.0.0.1 cannot be bound: 127.0.0.1 is not an address of this host.",
+			},
+		},
+		{
+			name:    "remote runtime",
+			cluster: "new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			reportHostPortConflicts([]hostPortBinding{apiServer}, tt.cluster, tt.others, tt.probe, &diags)
+
+			var errors, warnings []string
+
+			for _, d := range diags.Errors() {
+				errors = append(errors, d.Detail())
+			}
+
+			for _, d := range diags.Warnings() {
+				warnings = append(warnings, d.Detail())
+			}
+
+			assert.Equal(t, tt.errors, errors)
+			assert.Equal(t, tt.warnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,505 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Preflight check names.
+	preflightRuntimeCLI        = "runtime_cli"
+	preflightRuntimeDaemon     = "runtime_daemon"
+	preflightCgroupVersion     = "cgroup_version"
+	preflightCgroupDelegation  = "cgroup_delegation"
+	preflightInotifyInstances  = "inotify_max_user_instances"
+	preflightInotifyWatches    = "inotify_max_user_watches"
+	preflightRuntimeDiskSpace  = "disk_space"
+	preflightStatusPass        = "pass"
+	preflightStatusWarn        = "warn"
+	preflightStatusFail        = "fail"
+	preflightStatusSkip        = "skip"
+	preflightSkippedRemoteHost = "The runtime does not run on this host."
+
+	// minInotifyInstances and minInotifyWatches are the inotify limits kind recommends,
+	// lower limits make kubelet and kube-proxy fail with "too many open files".
+	minInotifyInstances = 512
+	minInotifyWatches   = 524288
+	// minFreeDiskSpace fails and lowFreeDiskSpace warns about the free space of the runtime data root.
+	minFreeDiskSpace = 2 << 30
+	lowFreeDiskSpace = 10 << 30
+	// cgroupV1UnsupportedMinor is the first Kubernetes minor whose kubelet refuses to start on cgroup v1.
+	cgroupV1UnsupportedMinor = 35
+	// cgroupRoot is the cgroup v2 mount read for the controllers delegated to rootless runtimes.
+	cgroupRoot = "/sys/fs/cgroup"
+)
+
+// preflightCheckType is the element type of the checks attribute of the kind_preflight data source.
+//
+//nolint:gochecknoglobals // constant attribute type
+var preflightCheckType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":        types.StringType,
+	"status":      types.StringType,
+	"message":     types.StringType,
+	"remediation": types.StringType,
+}}
+
+// requiredCgroupControllers are the controllers kind needs delegated to rootless runtimes.
+//
+//nolint:gochecknoglobals // constant list
+var requiredCgroupControllers = []string{"cpu", "memory", "pids"}
+
+// preflightCheck is the outcome of one preflight check.
+type preflightCheck struct {
+	Name        string
+	Status      string
+	Message     string
+	Remediation string
+}
+
+// runtimeHostInfo is what the runtime reports about the host it runs containers on.
+type runtimeHostInfo struct {
+	CgroupVersion     string
+	DataRoot          string
+	CgroupControllers []string
+	Rootless          bool
+	DesktopVM         bool
+}
+
+// runtimeInfoOutput decodes the info output of docker and nerdctl (top-level fields) and of
+// podman (host and store sections). Field names are matched case-insensitively.
+type runtimeInfoOutput struct {
+	CgroupVersion   string   `json:"cgroupVersion"`
+	DockerRootDir   string   `json:"dockerRootDir"`
+	OperatingSystem string   `json:"operatingSystem"`
+	SecurityOptions []string `json:"securityOptions"`
+	Host            struct {
+		CgroupVersion     string   `json:"cgroupVersion"`
+		CgroupControllers []string `json:"cgroupControllers"`
+		Security          struct {
+			Rootless bool `json:"rootless"`
+		} `json:"security"`
+	} `json:"host"`
+	Store struct {
+		GraphRoot string `json:"graphRoot"`
+	} `json:"store"`
+}
+
+// preflightFacts holds everything the preflight checks are evaluated from. Host facts are
+// only gathered when the runtime runs on this Linux host, zero values mean unknown.
+type preflightFacts struct {
+	RuntimeErr           error
+	Info                 *runtimeHostInfo
+	InfoErr              error
+	Binary               string
+	DelegatedControllers []string
+	InotifyInstances     int
+	InotifyWatches       int
+	FreeDiskSpace        uint64
+	KubernetesMinor      int
+	LocalHost            bool
+	DiskSpaceKnown       bool
+}
+
+// parseRuntimeInfo parses the output of the runtime info command.
+func parseRuntimeInfo(output string) (*runtimeHostInfo, error) {
+	var decoded runtimeInfoOutput
+
+	err := json.Unmarshal([]byte(output), &decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode runtime info: %w", err)
+	}
+
+	info := &runtimeHostInfo{
+		CgroupVersion:     strings.TrimPrefix(cmp.Or(decoded.CgroupVersion, decoded.Host.CgroupVersion), "v"),
+		DataRoot:          cmp.Or(decoded.DockerRootDir, decoded.Store.GraphRoot),
+		CgroupControllers: decoded.Host.CgroupControllers,
+		Rootless:          decoded.Host.Security.Rootless || slices.Contains(decoded.SecurityOptions, "name=rootless"),
+		DesktopVM:         strings.Contains(decoded.OperatingSystem, "Docker Desktop"),
+	}
+
+	return info, nil
+}
+
+// nodeImageMinor returns the Kubernetes minor version of a kindest/node image reference
+// (ex: 34 for kindest/node:v1.34.0@sha256:...), 0 when the tag is not a version.
+func nodeImageMinor(image string) int {
+	reference, _, _ := strings.Cut(image, "@")
+
+	_, tag, found := strings.Cut(reference[strings.LastIndex(reference, "/")+1:], ":")
+	if !found {
+		return 0
+	}
+
+	parts := strings.Split(strings.TrimPrefix(tag, "v"), ".")
+	if len(parts) < 2 || parts[0] != "1" { //nolint:mnd // major and minor
+		return 0
+	}
+
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0
+	}
+
+	return minor
+}
+
+// readIntFile reads a file holding a single integer, such as a sysctl under /proc/sys.
+func readIntFile(name string) int {
+	content, err := os.ReadFile(name) // #nosec G304 -- fixed procfs and cgroupfs paths
+	if err != nil {
+		return 0
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+// delegatedControllers returns the cgroup controllers systemd delegates to the user
+// service of uid, nil when they cannot be read.
+func delegatedControllers(root string, uid int) []string {
+	user := strconv.Itoa(uid)
+
+	content, err := os.ReadFile(filepath.Join( // #nosec G304 -- fixed cgroupfs path
+		root, "user.slice", "user-"+user+".slice", "user@"+user+".service", "cgroup.controllers",
+	))
+	if err != nil {
+		return nil
+	}
+
+	return strings.Fields(string(content))
+}
+
+// preflightFacts gathers the facts of the preflight checks for the runtime. remoteHost is
+// the address of a remote runtime host, or empty for a local runtime.
+func (r *kindRuntime) preflightFacts(ctx context.Context, remoteHost, nodeImage string) preflightFacts {
+	facts := preflightFacts{KubernetesMinor: nodeImageMinor(nodeImage)}
+
+	facts.Binary, facts.RuntimeErr = runtimeBinary(r.name)
+	if facts.RuntimeErr == nil {
+		_, facts.RuntimeErr = exec.LookPath(facts.Binary)
+	}
+
+	if facts.RuntimeErr != nil {
+		return facts
+	}
+
+	output, err := r.containerCommand(ctx, "info", "--format", "{{json .}}")
+	if err != nil {
+		facts.InfoErr = err
+
+		return facts
+	}
+
+	facts.Info, facts.InfoErr = parseRuntimeInfo(output)
+	if facts.InfoErr != nil {
+		return facts
+	}
+
+	facts.LocalHost = remoteHost == "" && goruntime.GOOS == "linux" && !facts.Info.DesktopVM
+	if !facts.LocalHost {
+		return facts
+	}
+
+	facts.InotifyInstances = readIntFile(filepath.Join(procRoot, "sys", "fs", "inotify", "max_user_instances"))
+	facts.InotifyWatches = readIntFile(filepath.Join(procRoot, "sys", "fs", "inotify", "max_user_watches"))
+
+	if facts.Info.Rootless && facts.Info.CgroupControllers == nil {
+		facts.DelegatedControllers = delegatedControllers(cgroupRoot, os.Getuid())
+	}
+
+	if facts.Info.DataRoot != "" {
+		facts.FreeDiskSpace, err = freeDiskSpace(facts.Info.DataRoot)
+		facts.DiskSpaceKnown = err == nil
+	}
+
+	return facts
+}
+
+// preflight runs the preflight checks for the runtime.
+func (r *kindRuntime) preflight(ctx context.Context, remoteHost, nodeImage string) []preflightCheck {
+	return evaluatePreflight(r.preflightFacts(ctx, remoteHost, nodeImage))
+}
+
+// evaluatePreflight turns the gathered facts into one check per known failure cause of kind
+// clusters. Checks that cannot be evaluated are skipped.
+func evaluatePreflight(facts preflightFacts) []preflightCheck {
+	checks := []preflightCheck{runtimeCLICheck(facts)}
+
+	if facts.RuntimeErr != nil {
+		return appendSkipped(checks, "The runtime CLI was not found.",
+			preflightRuntimeDaemon, preflightCgroupVersion, preflightCgroupDelegation,
+			preflightInotifyInstances, preflightInotifyWatches, preflightRuntimeDiskSpace)
+	}
+
+	if facts.InfoErr != nil {
+		checks = append(checks, preflightCheck{
+			Name:    preflightRuntimeDaemon,
+			Status:  preflightStatusFail,
+			Message: facts.Binary + " info failed: " + facts.InfoErr.Error(),
+			Remediation: "Start the container runtime (ex: systemctl start " + facts.Binary + "), " +
+				"and check docker_host, docker_context and the permissions on the runtime socket.",
+		})
+
+		return appendSkipped(checks, "The runtime could not be reached.",
+			preflightCgroupVersion, preflightCgroupDelegation,
+			preflightInotifyInstances, preflightInotifyWatches, preflightRuntimeDiskSpace)
+	}
+
+	checks = append(checks,
+		preflightCheck{
+			Name:    preflightRuntimeDaemon,
+			Status:  preflightStatusPass,
+			Message: "The " + facts.Binary + " runtime is reachable.",
+		},
+		cgroupVersionCheck(facts),
+		cgroupDelegationCheck(facts),
+		inotifyCheck(preflightInotifyInstances, facts.LocalHost, facts.InotifyInstances, minInotifyInstances),
+		inotifyCheck(preflightInotifyWatches, facts.LocalHost, facts.InotifyWatches, minInotifyWatches),
+		diskSpaceCheck(facts),
+	)
+
+	return checks
+}
+
+// appendSkipped appends the named checks as skipped for the reason.
+func appendSkipped(checks []preflightCheck, reason string, names ...string) []preflightCheck {
+	for _, name := range names {
+		checks = append(checks, preflightCheck{Name: name, Status: preflightStatusSkip, Message: reason})
+	}
+
+	return checks
+}
+
+// runtimeCLICheck checks that the runtime CLI kind shells out to is installed.
+func runtimeCLICheck(facts preflightFacts) preflightCheck {
+	if facts.RuntimeErr != nil {
+		return preflightCheck{
+			Name:    preflightRuntimeCLI,
+			Status:  preflightStatusFail,
+			Message: facts.RuntimeErr.Error(),
+			Remediation: "Install docker, podman or nerdctl on the PATH of the Terraform process, " +
+				"or set runtime to the one that is installed.",
+		}
+	}
+
+	return preflightCheck{
+		Name:    preflightRuntimeCLI,
+		Status:  preflightStatusPass,
+		Message: "Found " + facts.Binary + ".",
+	}
+}
+
+// cgroupVersionCheck checks that the runtime host uses cgroup v2. cgroup v1 fails for node
+// images whose kubelet refuses it, and is deprecated for the older ones.
+func cgroupVersionCheck(facts preflightFacts) preflightCheck {
+	check := preflightCheck{Name: preflightCgroupVersion}
+
+	switch facts.Info.CgroupVersion {
+	case "":
+		check.Status = preflightStatusSkip
+		check.Message = "The runtime does not report the cgroup version."
+	case "1":
+		check.Status = preflightStatusWarn
+		check.Message = "The runtime host uses cgroup v1, which Kubernetes deprecated."
+		check.Remediation = "Boot the runtime host with the unified cgroup hierarchy " +
+			"(systemd.unified_cgroup_hierarchy=1 on the kernel command line)."
+
+		if facts.KubernetesMinor >= cgroupV1UnsupportedMinor {
+			check.Status = preflightStatusFail
+			check.Message = fmt.Sprintf("The runtime host uses cgroup v1, which the kubelet of Kubernetes v1.%d refuses.",
+				facts.KubernetesMinor)
+			check.Remediation += fmt.Sprintf(" Alternatively use a node image older than v1.%d.", cgroupV1UnsupportedMinor)
+		}
+	default:
+		check.Status = preflightStatusPass
+		check.Message = "The runtime host uses cgroup v" + facts.Info.CgroupVersion + "."
+	}
+
+	return check
+}
+
+// cgroupDelegationCheck checks that rootless runtimes have the cgroup controllers
+// delegated that the kubelet needs inside the nodes.
+func cgroupDelegationCheck(facts preflightFacts) preflightCheck {
+	check := preflightCheck{Name: preflightCgroupDelegation}
+
+	if !facts.Info.Rootless {
+		check.Status = preflightStatusSkip
+		check.Message = "The runtime is not rootless."
+
+		return check
+	}
+
+	controllers := facts.Info.CgroupControllers
+	if controllers == nil {
+		controllers = facts.DelegatedControllers
+	}
+
+	if controllers == nil {
+		check.Status = preflightStatusSkip
+		check.Message = "The delegated cgroup controllers could not be read."
+
+		return check
+	}
+
+	var missing []string
+
+	for _, controller := range requiredCgroupControllers {
+		if !slices.Contains(controllers, controller) {
+			missing = append(missing, controller)
+		}
+	}
+
+	if len(missing) == 0 {
+		check.Status = preflightStatusPass
+		check.Message = "The rootless runtime has the " + strings.Join(requiredCgroupControllers, ", ") +
+			" cgroup controllers delegated."
+
+		return check
+	}
+
+	check.Status = preflightStatusFail
+	check.Message = "The rootless runtime lacks the " + strings.Join(missing, ", ") + " cgroup controllers."
+	check.Remediation = "Delegate the cgroup controllers to user sessions: write \"[Service]\\nDelegate=yes\" " +
+		"to /etc/systemd/system/user@.service.d/delegate.conf, run systemctl daemon-reload, then log in again."
+
+	return check
+}
+
+// inotifyCheck checks an inotify limit of the runtime host against the value kind recommends.
+func inotifyCheck(name string, localHost bool, value, minimum int) preflightCheck {
+	check := preflightCheck{Name: name}
+
+	switch {
+	case !localHost:
+		check.Status = preflightStatusSkip
+		check.Message = preflightSkippedRemoteHost
+	case value == 0:
+		check.Status = preflightStatusSkip
+		check.Message = "fs.inotify." + strings.TrimPrefix(name, "inotify_") + " could not be read."
+	case value < minimum:
+		sysctl := "fs.inotify." + strings.TrimPrefix(name, "inotify_")
+		check.Status = preflightStatusWarn
+		check.Message = fmt.Sprintf("%s is %d, below %d. Nodes may fail with \"too many open files\".",
+			sysctl, value, minimum)
+		check.Remediation = fmt.Sprintf("Run sysctl %s=%d and persist it in /etc/sysctl.d.", sysctl, minimum)
+	default:
+		check.Status = preflightStatusPass
+		check.Message = fmt.Sprintf("fs.inotify.%s is %d.", strings.TrimPrefix(name, "inotify_"), value)
+	}
+
+	return check
+}
+
+// diskSpaceCheck checks the free space under the runtime data root, where the node images
+// and the node containers live.
+func diskSpaceCheck(facts preflightFacts) preflightCheck {
+	check := preflightCheck{Name: preflightRuntimeDiskSpace}
+
+	switch {
+	case !facts.LocalHost:
+		check.Status = preflightStatusSkip
+		check.Message = preflightSkippedRemoteHost
+
+		return check
+	case !facts.DiskSpaceKnown:
+		check.Status = preflightStatusSkip
+		check.Message = "The free space of the runtime data root could not be read."
+
+		return check
+	}
+
+	free := fmt.Sprintf("%.1f GiB free under %s", float64(facts.FreeDiskSpace)/(1<<30), facts.Info.DataRoot)
+	remediation := "Free space under " + facts.Info.DataRoot + ", for example with " + facts.Binary + " system prune."
+
+	switch {
+	case facts.FreeDiskSpace < minFreeDiskSpace:
+		check.Status = preflightStatusFail
+		check.Message = "Only " + free + ", the node image and containers do not fit."
+		check.Remediation = remediation
+	case facts.FreeDiskSpace < lowFreeDiskSpace:
+		check.Status = preflightStatusWarn
+		check.Message = "Only " + free + ", the kubelet may evict pods under disk pressure."
+		check.Remediation = remediation
+	default:
+		check.Status = preflightStatusPass
+		check.Message = free + "."
+	}
+
+	return check
+}
+
+// preflightDiagnostics reports the failed checks as errors and the warnings as warnings.
+func preflightDiagnostics(checks []preflightCheck) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, check := range checks {
+		detail := strings.TrimSpace(check.Message + " " + check.Remediation)
+
+		switch check.Status {
+		case preflightStatusFail:
+			diags.AddError("Preflight check "+check.Name+" failed", detail)
+		case preflightStatusWarn:
+			diags.AddWarning("Preflight check "+check.Name+" warning", detail)
+		}
+	}
+
+	return diags
+}
+
+// preflightChecksValue builds the checks attribute of the kind_preflight data source.
+func preflightChecksValue(checks []preflightCheck) (types.List, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(checks))
+
+	var diags diag.Diagnostics
+
+	for _, check := range checks {
+		element, objectDiags := types.ObjectValue(preflightCheckType.AttrTypes, map[string]attr.Value{
+			"name":        types.StringValue(check.Name),
+			"status":      types.StringValue(check.Status),
+			"message":     types.StringValue(check.Message),
+			"remediation": types.StringValue(check.Remediation),
+		})
+		diags.Append(objectDiags...)
+
+		elements = append(elements, element)
+	}
+
+	list, listDiags := types.ListValue(preflightCheckType, elements)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
@@ -0,0 +1,335 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// podmanInfo is a trimmed podman info output of a rootless host.
+const podmanInfo = `{
+  "host": {
+    "cgroupVersion": "v2",
+    "cgroupControllers": ["memory", "pids"],
+    "security": {"rootless": true}
+  },
+  "store": {"graphRoot": "/home/ci/.local/share/containers/storage"}
+}`
+
+func TestParseRuntimeInfo(t *testing.T) {
+	tests := []struct {
+		expected *runtimeHostInfo
+		name     string
+		output   string
+	}{
+		{
+			name:   "docker",
+			output: `{"CgroupVersion":"2","DockerRootDir":"/var/lib/docker","OperatingSystem":"Ubuntu 24.04 LTS","SecurityOptions":["name=seccomp,profile=builtin","name=cgroupns"]}`,
+			expected: &runtimeHostInfo{
+				CgroupVersion: "2",
+				DataRoot:      "/var/lib/docker",
+			},
+		},
+		{
+			name:   "rootless docker desktop",
+			output: `{"CgroupVersion":"1","DockerRootDir":"/var/lib/docker","OperatingSystem":"Docker Desktop","SecurityOptions":["name=rootless"]}`,
+			expected: &runtimeHostInfo{
+				CgroupVersion: "1",
+				DataRoot:      "/var/lib/docker",
+				Rootless:      true,
+				DesktopVM:     true,
+			},
+		},
+		{
+			name:   "podman",
+			output: podmanInfo,
+			expected: &runtimeHostInfo{
+				CgroupVersion:     "2",
+				DataRoot:          "/home/ci/.local/share/containers/storage",
+				CgroupControllers: []string{"memory", "pids"},
+				Rootless:          true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := parseRuntimeInfo(tt.output)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, info)
+		})
+	}
+
+	_, err := parseRuntimeInfo("Cannot connect to the Docker daemon")
+	require.Error(t, err)
+}
+
+func TestNodeImageMinor(t *testing.T) {
+	tests := map[string]int{
+		defaultNodeImage:                          34,
+		"kindest/node:v1.35.1":                    35,
+		"localhost:5000/kindest/node:v1.36.0-rc1": 36,
+		"localhost:5000/kindest/node":             0,
+		"kindest/node:latest":                     0,
+	}
+
+	for image, expected := range tests {
+		t.Run(image, func(t *testing.T) {
+			assert.Equal(t, expected, nodeImageMinor(image))
+		})
+	}
+}
+
+func TestReadIntFile(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "max_user_instances"), []byte("128\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "garbage"), []byte("many\n"), 0o600))
+
+	assert.Equal(t, 128, readIntFile(filepath.Join(dir, "max_user_instances")))
+	assert.Zero(t, readIntFile(filepath.Join(dir, "garbage")))
+	assert.Zero(t, readIntFile(filepath.Join(dir, "missing")))
+}
+
+func TestDelegatedControllers(t *testing.T) {
+	root := t.TempDir()
+	service := filepath.Join(root, "user.slice", "user-1000.slice", "user@1000.service")
+
+	require.NoError(t, os.MkdirAll(service, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(service, "cgroup.controllers"), []byte("memory pids\n"), 0o600))
+
+	assert.Equal(t, []string{"memory", "pids"}, delegatedControllers(root, 1000))
+	assert.Nil(t, delegatedControllers(root, 1001))
+}
+
+func TestFreeDiskSpace(t *testing.T) {
+	free, err := freeDiskSpace(t.TempDir())
+	require.NoError(t, err)
+	assert.Positive(t, free)
+
+	_, err = freeDiskSpace(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestEvaluatePreflight(t *testing.T) {
+	// healthy returns the facts of a local host every check passes on
+	healthy := func() preflightFacts {
+		return preflightFacts{
+			Binary:           "docker",
+			Info:             &runtimeHostInfo{CgroupVersion: "2", DataRoot: "/var/lib/docker"},
+			LocalHost:        true,
+			InotifyInstances: 8192,
+			InotifyWatches:   1048576,
+			FreeDiskSpace:    100 << 30,
+			DiskSpaceKnown:   true,
+			KubernetesMinor:  34,
+		}
+	}
+
+	tests := []struct {
+		modify   func(*preflightFacts)
+		expected map[string]string
+		name     string
+	}{
+		{
+			name: "healthy",
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusPass,
+				preflightCgroupVersion:    preflightStatusPass,
+				preflightCgroupDelegation: preflightStatusSkip,
+				preflightInotifyInstances: preflightStatusPass,
+				preflightInotifyWatches:   preflightStatusPass,
+				preflightRuntimeDiskSpace: preflightStatusPass,
+			},
+		},
+		{
+			name: "missing runtime",
+			modify: func(facts *preflightFacts) {
+				facts.RuntimeErr = ErrNoRuntimeDetected
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusFail,
+				preflightRuntimeDaemon:    preflightStatusSkip,
+				preflightCgroupVersion:    preflightStatusSkip,
+				preflightCgroupDelegation: preflightStatusSkip,
+				preflightInotifyInstances: preflightStatusSkip,
+				preflightInotifyWatches:   preflightStatusSkip,
+				preflightRuntimeDiskSpace: preflightStatusSkip,
+			},
+		},
+		{
+			name: "unreachable daemon",
+			modify: func(facts *preflightFacts) {
+				facts.Info = nil
+				facts.InfoErr = errors.New("Cannot connect to the Docker daemon")
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusFail,
+				preflightCgroupVersion:    preflightStatusSkip,
+				preflightCgroupDelegation: preflightStatusSkip,
+				preflightInotifyInstances: preflightStatusSkip,
+				preflightInotifyWatches:   preflightStatusSkip,
+				preflightRuntimeDiskSpace: preflightStatusSkip,
+			},
+		},
+		{
+			name: "cgroup v1 with an old node image, low limits and little space",
+			modify: func(facts *preflightFacts) {
+				facts.Info.CgroupVersion = "1"
+				facts.InotifyInstances = 128
+				facts.InotifyWatches = 8192
+				facts.FreeDiskSpace = 5 << 30
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusPass,
+				preflightCgroupVersion:    preflightStatusWarn,
+				preflightCgroupDelegation: preflightStatusSkip,
+				preflightInotifyInstances: preflightStatusWarn,
+				preflightInotifyWatches:   preflightStatusWarn,
+				preflightRuntimeDiskSpace: preflightStatusWarn,
+			},
+		},
+		{
+			name: "cgroup v1 with a recent node image and a full disk",
+			modify: func(facts *preflightFacts) {
+				facts.Info.CgroupVersion = "1"
+				facts.KubernetesMinor = 35
+				facts.FreeDiskSpace = 1 << 30
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusPass,
+				preflightCgroupVersion:    preflightStatusFail,
+				preflightCgroupDelegation: preflightStatusSkip,
+				preflightInotifyInstances: preflightStatusPass,
+				preflightInotifyWatches:   preflightStatusPass,
+				preflightRuntimeDiskSpace: preflightStatusFail,
+			},
+		},
+		{
+			name: "rootless podman without delegation",
+			modify: func(facts *preflightFacts) {
+				facts.Binary = "podman"
+				facts.Info.Rootless = true
+				facts.Info.CgroupControllers = []string{"memory", "pids"}
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusPass,
+				preflightCgroupVersion:    preflightStatusPass,
+				preflightCgroupDelegation: preflightStatusFail,
+				preflightInotifyInstances: preflightStatusPass,
+				preflightInotifyWatches:   preflightStatusPass,
+				preflightRuntimeDiskSpace: preflightStatusPass,
+			},
+		},
+		{
+			name: "rootless docker with delegation",
+			modify: func(facts *preflightFacts) {
+				facts.Info.Rootless = true
+				facts.DelegatedControllers = []string{"cpuset", "cpu", "io", "memory", "pids"}
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusPass,
+				preflightCgroupVersion:    preflightStatusPass,
+				preflightCgroupDelegation: preflightStatusPass,
+				preflightInotifyInstances: preflightStatusPass,
+				preflightInotifyWatches:   preflightStatusPass,
+				preflightRuntimeDiskSpace: preflightStatusPass,
+			},
+		},
+		{
+			name: "remote runtime",
+			modify: func(facts *preflightFacts) {
+				facts.LocalHost = false
+				facts.InotifyInstances = 0
+				facts.InotifyWatches = 0
+				facts.DiskSpaceKnown = false
+			},
+			expected: map[string]string{
+				preflightRuntimeCLI:       preflightStatusPass,
+				preflightRuntimeDaemon:    preflightStatusPass,
+				preflightCgroupVersion:    preflightStatusPass,
+				preflightCgroupDelegation: preflightStatusSkip,
+				preflightInotifyInstances: preflightStatusSkip,
+				preflightInotifyWatches:   preflightStatusSkip,
+				preflightRuntimeDiskSpace: preflightStatusSkip,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facts := healthy()
+			if tt.modify != nil {
+				tt.modify(&facts)
+			}
+
+			statuses := make(map[string]string)
+
+			for _, check := range evaluatePreflight(facts) {
+				statuses[check.Name] = check.Status
+
+				if check.Status == preflightStatusFail || check.Status == preflightStatusWarn {
+					assert.NotEmpty(t, check.Remediation, check.Name)
+				}
+			}
+
+			assert.Equal(t, tt.expected, statuses)
+		})
+	}
+}
+
+func TestPreflightDiagnostics(t *testing.T) {
+	diags := preflightDiagnostics([]preflightCheck{
+		{Name: preflightRuntimeCLI, Status: preflightStatusPass, Message: "Found docker."},
+		{
+			Name:        preflightInotifyInstances,
+			Status:      preflightStatusWarn,
+			Message:     "fs.inotify.max_user_instances is 128, below 512.",
+			Remediation: "Run sysctl fs.inotify.max_user_instances=512 and persist it in /etc/sysctl.d.",
+		},
+		{
+			Name:        preflightRuntimeDiskSpace,
+			Status:      preflightStatusFail,
+			Message:     "Only 1.0 GiB free under /var/lib/docker, the node image and containers do not fit.",
+			Remediation: "Free space under /var/lib/docker, for example with docker system prune.",
+		},
+		{Name: preflightCgroupDelegation, Status: preflightStatusSkip, Message: "The runtime is not rootless."},
+	})
+
+	require.Len(t, diags.Errors(), 1)
+	assert.Equal(t, "Preflight check disk_space failed", diags.Errors()[0].Summary())
+	assert.Equal(t,
+		"Only 1.0 GiB free under /var/lib/docker, the node image and containers do not fit. "+
+			"Free space under /var/lib/docker, for example with docker system prune.",
+		diags.Errors()[0].Detail(),
+	)
+
+	require.Len(t, diags.Warnings(), 1)
+	assert.Equal(t, "Preflight check inotify_max_user_instances warning", diags.Warnings()[0].Summary())
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Compile-time check to ensure KindProvider satisfies the provider.Provider interface.
@@ -32,28 +33,52 @@ var (
 )
 
 // KindProvider is the provider implementation using Plugin Framework.
-type KindProvider struct {
-	// version is set to the provider version on release, "dev" when the
-	// provider is built and ran locally, and "test" when running acceptance tests
-	version string
-}
+// KindProviderModel describes the provider configuration data model.
+type (
+	KindProvider struct {
+		// version is set to the provider version on release, "dev" when the
+		// provider is built and ran locally, and "test" when running acceptance tests
+		version string
+	}
+
+	KindProviderModel struct {
+		DockerHost    types.String `tfsdk:"docker_host"`
+		DockerContext types.String `tfsdk:"docker_context"`
+	}
+)
 
 // Configure prepares the provider for data sources and resources.
 //
 
 func (*KindProvider) Configure(
-	_ context.Context,
-	_ provider.ConfigureRequest,
-	_ *provider.ConfigureResponse,
+	ctx context.Context,
+	req provider.ConfigureRequest,
+	resp *provider.ConfigureResponse,
 ) {
-	// Provider has no configuration, so nothing to do here
-	// If we needed to configure clients, we would do it here and store in resp.ResourceData
+	var data KindProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Runtime settings are handed to resources, which fall back to them when unset
+	settings := &runtimeSettings{
+		DockerHost:    data.DockerHost.ValueString(),
+		DockerContext: data.DockerContext.ValueString(),
+	}
+
+	resp.ResourceData = settings
+	resp.DataSourceData = settings
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (*KindProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	// No data sources yet
-	return make([]func() datasource.DataSource, 0)
+	return []func() datasource.DataSource{
+		NewNetworkDataSource,
+		NewPreflightDataSource,
+	}
 }
 
 // Metadata returns the provider type name.
@@ -70,6 +95,11 @@ func (p *KindProvider) Metadata(
 func (*KindProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClusterResource,
+		NewClusterPeeringResource,
+		NewManifestResource,
+		NewNetworkResource,
+		NewNodeExecResource,
+		NewNodeFileResource,
 	}
 }
 
@@ -81,6 +111,16 @@ func (*KindProvider) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Description: "The Kind provider is used to manage Kind (Kubernetes IN Docker) clusters.",
+		Attributes: map[string]schema.Attribute{
+			"docker_host": schema.StringAttribute{
+				Optional:    true,
+				Description: "Container runtime host used by default for all resources (ex: ssh://user@build-box). Takes precedence over docker_context.",
+			},
+			"docker_context": schema.StringAttribute{
+				Optional:    true,
+				Description: "Docker context (or podman connection) used by default for all resources.",
+			},
+		},
 	}
 }
 
@@ -19,9 +19,11 @@ package kind
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const testVersion = "test"
@@ -93,3 +95,17 @@ func TestKindProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestKindProvider_Schema(t *testing.T) {
+	resp := &provider.SchemaResponse{}
+
+	(&KindProvider{}).Schema(t.Context(), provider.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "schema should not have diagnostics errors")
+
+	for _, name := range []string{"docker_host", "docker_context"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "provider schema must have %q attribute", name)
+		assert.True(t, attr.IsOptional(), "%s should be optional", name)
+	}
+}
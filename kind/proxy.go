@@ -0,0 +1,176 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Proxy environment variables kind passes into the node containers.
+const (
+	envHTTPProxy  = "HTTP_PROXY"
+	envHTTPSProxy = "HTTPS_PROXY"
+	envNoProxy    = "NO_PROXY"
+)
+
+// ErrInvalidProxy is returned when a proxy block is invalid.
+//
+//nolint:grouper // false positive
+var ErrInvalidProxy = errors.New("invalid proxy")
+
+// clusterProxy holds the settings of a proxy block.
+type clusterProxy struct {
+	HTTP    string
+	HTTPS   string
+	NoProxy string
+}
+
+// proxyBlock returns the proxy block schema.
+// kind bakes the proxy into the node containers, so any change replaces the cluster.
+func proxyBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "HTTP proxy of the node containers. Replaces the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables kind would otherwise read from the provider environment.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"http": schema.StringAttribute{
+				Optional:    true,
+				Description: "Proxy for HTTP requests (ex: http://proxy.corp:3128).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"https": schema.StringAttribute{
+				Optional:    true,
+				Description: "Proxy for HTTPS requests (ex: http://proxy.corp:3128).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Comma-separated hosts that bypass the proxy. The pod subnet, the service subnet, the node network and the cluster service domains are always added; when unset, this reports the resulting value.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// flattenProxy converts a proxy block into a clusterProxy, nil when the block is not set.
+func flattenProxy(proxy types.Object) *clusterProxy {
+	if proxy.IsNull() || proxy.IsUnknown() {
+		return nil
+	}
+
+	proxyMap := objectToMap(proxy)
+
+	return &clusterProxy{
+		HTTP:    getString(proxyMap, "http"),
+		HTTPS:   getString(proxyMap, "https"),
+		NoProxy: getString(proxyMap, "no_proxy"),
+	}
+}
+
+// validate checks that at least one proxy is set and that the proxies are URLs.
+func (p *clusterProxy) validate() error {
+	if p.HTTP == "" && p.HTTPS == "" {
+		return fmt.Errorf("%w: at least one of http or https must be set", ErrInvalidProxy)
+	}
+
+	for _, proxy := range []string{p.HTTP, p.HTTPS} {
+		if proxy == "" {
+			continue
+		}
+
+		parsed, err := url.Parse(proxy)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("%w: %q must be a URL (ex: http://proxy.corp:3128)", ErrInvalidProxy, proxy)
+		}
+	}
+
+	return nil
+}
+
+// env returns the proxy environment kind reads when it creates the node containers.
+// Every variable is set, in both cases, so nothing leaks in from the plugin process environment.
+func (p *clusterProxy) env() map[string]string {
+	env := make(map[string]string, 6) //nolint:mnd // three variables in two cases
+
+	for name, value := range map[string]string{
+		envHTTPProxy:  p.HTTP,
+		envHTTPSProxy: p.HTTPS,
+		envNoProxy:    p.NoProxy,
+	} {
+		env[name] = value
+		env[strings.ToLower(name)] = value
+	}
+
+	return env
+}
+
+// containerEnvValue returns the value of a variable in the output of a container
+// inspect listing the environment one NAME=value per line.
+func containerEnvValue(output, name string) string {
+	for line := range strings.Lines(output) {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), name+"="); found {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// setProxyNoProxy returns the proxy block with no_proxy set to the given value.
+func setProxyNoProxy(ctx context.Context, proxy types.Object, noProxy string) (types.Object, diag.Diagnostics) {
+	attributes := make(map[string]attr.Value, len(proxy.Attributes()))
+
+	for name, value := range proxy.Attributes() {
+		attributes[name] = value
+	}
+
+	attributes["no_proxy"] = types.StringValue(noProxy)
+
+	return types.ObjectValue(proxy.AttributeTypes(ctx), attributes)
+}
+
+// objectHasUnknown reports whether any attribute of the object is unknown.
+func objectHasUnknown(object types.Object) bool {
+	for _, value := range object.Attributes() {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // test fixture
+var testProxyAttrTypes = map[string]attr.Type{
+	"http":     types.StringType,
+	"https":    types.StringType,
+	"no_proxy": types.StringType,
+}
+
+func testProxyObject(t *testing.T, http, https string, noProxy attr.Value) types.Object {
+	t.Helper()
+
+	stringOrNull := func(value string) attr.Value {
+		if value == "" {
+			return types.StringNull()
+		}
+
+		return types.StringValue(value)
+	}
+
+	object, diags := types.ObjectValue(testProxyAttrTypes, map[string]attr.Value{
+		"http":     stringOrNull(http),
+		"https":    stringOrNull(https),
+		"no_proxy": noProxy,
+	})
+	require.False(t, diags.HasError())
+
+	return object
+}
+
+func TestFlattenProxy(t *testing.T) {
+	assert.Nil(t, flattenProxy(types.ObjectNull(testProxyAttrTypes)))
+	assert.Nil(t, flattenProxy(types.ObjectUnknown(testProxyAttrTypes)))
+
+	proxy := flattenProxy(testProxyObject(t, "http://proxy:3128", "", types.StringValue("corp.internal")))
+	assert.Equal(t, &clusterProxy{HTTP: "http://proxy:3128", NoProxy: "corp.internal"}, proxy)
+}
+
+func TestClusterProxyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   clusterProxy
+		wantErr bool
+	}{
+		{name: "http only", proxy: clusterProxy{HTTP: "http://proxy.corp:3128"}},
+		{name: "https only", proxy: clusterProxy{HTTPS: "https://proxy.corp:3129"}},
+		{name: "socks", proxy: clusterProxy{HTTP: "socks5://proxy.corp:1080", HTTPS: "socks5://proxy.corp:1080"}},
+		{name: "no proxy only", proxy: clusterProxy{NoProxy: "corp.internal"}, wantErr: true},
+		{name: "missing scheme", proxy: clusterProxy{HTTP: "proxy.corp:3128"}, wantErr: true},
+		{name: "missing host", proxy: clusterProxy{HTTPS: "http://"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.proxy.validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidProxy)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClusterProxyEnv(t *testing.T) {
+	proxy := clusterProxy{HTTP: "http://proxy:3128", NoProxy: "corp.internal"}
+
+	assert.Equal(t, map[string]string{
+		"HTTP_PROXY":  "http://proxy:3128",
+		"http_proxy":  "http://proxy:3128",
+		"HTTPS_PROXY": "",
+		"https_proxy": "",
+		"NO_PROXY":    "corp.internal",
+		"no_proxy":    "corp.internal",
+	}, proxy.env())
+}
+
+func TestKindRuntimeWithEnv(t *testing.T) {
+	runtime := &kindRuntime{env: map[string]string{"DOCKER_HOST": "tcp://docker:2376"}, name: "docker"}
+
+	proxied := runtime.withEnv(map[string]string{"HTTP_PROXY": "http://proxy:3128"})
+
+	assert.Equal(t, map[string]string{"DOCKER_HOST": "tcp://docker:2376"}, runtime.env)
+	assert.Equal(t, map[string]string{
+		"DOCKER_HOST": "tcp://docker:2376",
+		"HTTP_PROXY":  "http://proxy:3128",
+	}, proxied.env)
+	assert.Equal(t, "docker", proxied.name)
+	assert.NotEqual(t, runtimeEnvKey(runtime.env), runtimeEnvKey(proxied.env))
+}
+
+func TestContainerEnvValue(t *testing.T) {
+	output := "PATH=/usr/bin\nHTTP_PROXY=http://proxy:3128\nNO_PROXY=10.96.0.0/16,10.244.0.0/16,.svc\n\n"
+
+	assert.Equal(t, "10.96.0.0/16,10.244.0.0/16,.svc", containerEnvValue(output, "NO_PROXY"))
+	assert.Equal(t, "http://proxy:3128", containerEnvValue(output, "HTTP_PROXY"))
+	assert.Empty(t, containerEnvValue(output, "HTTPS_PROXY"))
+}
+
+func TestSetProxyNoProxy(t *testing.T) {
+	proxy := testProxyObject(t, "http://proxy:3128", "", types.StringUnknown())
+
+	updated, diags := setProxyNoProxy(context.Background(), proxy, "10.96.0.0/16")
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, testProxyObject(t, "http://proxy:3128", "", types.StringValue("10.96.0.0/16")), updated)
+	assert.True(t, objectHasUnknown(proxy))
+	assert.False(t, objectHasUnknown(updated))
+}
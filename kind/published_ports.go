@@ -0,0 +1,168 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiServerContainerPort is the port the API server, or the external load balancer of HA
+// clusters, listens on inside its container.
+const apiServerContainerPort = 6443
+
+// publishedPortType is the element type of the published_ports attribute.
+//
+//nolint:gochecknoglobals // constant attribute type
+var publishedPortType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"node":           types.StringType,
+	"container_port": types.Int64Type,
+	"protocol":       types.StringType,
+	"host_port":      types.Int64Type,
+	"listen_address": types.StringType,
+}}
+
+// publishedPort is a container port of a node published on the runtime host.
+type publishedPort struct {
+	Node          string
+	Protocol      string
+	ListenAddress string
+	ContainerPort int
+	HostPort      int
+}
+
+// parseContainerPorts parses the output of the runtime port command for a node, one
+// "80/tcp -> 0.0.0.0:32768" line per binding. Bindings that only differ by their listen
+// address (ex: 0.0.0.0 and [::]) are reported once.
+func parseContainerPorts(node, output string) []publishedPort {
+	var ports []publishedPort
+
+	for line := range strings.Lines(output) {
+		containerSide, hostSide, found := strings.Cut(strings.TrimSpace(line), " -> ")
+		if !found {
+			continue
+		}
+
+		containerPortValue, protocol, _ := strings.Cut(containerSide, "/")
+
+		containerPort, err := strconv.Atoi(containerPortValue)
+		if err != nil {
+			continue
+		}
+
+		listenAddress, hostPortValue, err := net.SplitHostPort(hostSide)
+		if err != nil {
+			continue
+		}
+
+		hostPort, err := strconv.Atoi(hostPortValue)
+		if err != nil {
+			continue
+		}
+
+		port := publishedPort{
+			Node:          node,
+			Protocol:      strings.ToUpper(cmp.Or(protocol, "tcp")),
+			ListenAddress: listenAddress,
+			ContainerPort: containerPort,
+			HostPort:      hostPort,
+		}
+
+		if !slices.ContainsFunc(ports, func(other publishedPort) bool {
+			return other.ContainerPort == port.ContainerPort && other.Protocol == port.Protocol && other.HostPort == port.HostPort
+		}) {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+// publishedPorts returns the published ports of every container of the cluster, sorted by
+// node and container port.
+func (r *kindRuntime) publishedPorts(ctx context.Context, clusterName string) ([]publishedPort, error) {
+	nodeList, err := r.allNodes(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []publishedPort
+
+	for _, node := range nodeList {
+		output, portErr := r.containerCommand(ctx, "port", node.String())
+		if portErr != nil {
+			return nil, portErr
+		}
+
+		ports = append(ports, parseContainerPorts(node.String(), output)...)
+	}
+
+	slices.SortFunc(ports, func(a, b publishedPort) int {
+		return cmp.Or(
+			cmp.Compare(a.Node, b.Node),
+			cmp.Compare(a.ContainerPort, b.ContainerPort),
+			cmp.Compare(a.Protocol, b.Protocol),
+			cmp.Compare(a.HostPort, b.HostPort),
+		)
+	})
+
+	return ports, nil
+}
+
+// apiServerHostPort returns the host port the API server is published on, 0 when it is not.
+func apiServerHostPort(ports []publishedPort) int {
+	for _, port := range ports {
+		if port.ContainerPort == apiServerContainerPort && port.Protocol == "TCP" {
+			return port.HostPort
+		}
+	}
+
+	return 0
+}
+
+// publishedPortsValue builds the published_ports attribute.
+func publishedPortsValue(ports []publishedPort) (types.List, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(ports))
+
+	var diags diag.Diagnostics
+
+	for _, port := range ports {
+		element, objectDiags := types.ObjectValue(publishedPortType.AttrTypes, map[string]attr.Value{
+			"node":           types.StringValue(port.Node),
+			"container_port": types.Int64Value(int64(port.ContainerPort)),
+			"protocol":       types.StringValue(port.Protocol),
+			"host_port":      types.Int64Value(int64(port.HostPort)),
+			"listen_address": types.StringValue(port.ListenAddress),
+		})
+		diags.Append(objectDiags...)
+
+		elements = append(elements, element)
+	}
+
+	list, listDiags := types.ListValue(publishedPortType, elements)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
@@ -0,0 +1,66 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerPorts(t *testing.T) {
+	output := `80/tcp -> 0.0.0.0:32768
+80/tcp -> [::]:32768
+53/udp -> 127.0.0.1:5353
+6443/tcp -> 127.0.0.1:41235
+garbage
+`
+
+	assert.Equal(t, []publishedPort{
+		{Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "0.0.0.0", ContainerPort: 80, HostPort: 32768},
+		{Node: "dev-control-plane", Protocol: "UDP", ListenAddress: "127.0.0.1", ContainerPort: 53, HostPort: 5353},
+		{Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "127.0.0.1", ContainerPort: 6443, HostPort: 41235},
+	}, parseContainerPorts("dev-control-plane", output))
+
+	assert.Empty(t, parseContainerPorts("dev-worker", ""))
+}
+
+func TestAPIServerHostPort(t *testing.T) {
+	ports := []publishedPort{
+		{Node: "dev-control-plane", Protocol: "TCP", ContainerPort: 80, HostPort: 32768},
+		{Node: "dev-external-load-balancer", Protocol: "TCP", ContainerPort: apiServerContainerPort, HostPort: 41235},
+	}
+
+	assert.Equal(t, 41235, apiServerHostPort(ports))
+	assert.Zero(t, apiServerHostPort(ports[:1]))
+}
+
+func TestPublishedPortsValue(t *testing.T) {
+	list, diags := publishedPortsValue([]publishedPort{
+		{Node: "dev-control-plane", Protocol: "TCP", ListenAddress: "0.0.0.0", ContainerPort: 80, HostPort: 32768},
+	})
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, []any{map[string]any{
+		"node":           "dev-control-plane",
+		"container_port": 80,
+		"protocol":       "TCP",
+		"host_port":      32768,
+		"listen_address": "0.0.0.0",
+	}}, listToSlice(list))
+}
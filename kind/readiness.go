@@ -0,0 +1,300 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tfschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// controlPlaneNodeSelector selects the control plane nodes.
+	controlPlaneNodeSelector = "node-role.kubernetes.io/control-plane"
+	// coreDNSNamespace and coreDNSDeployment identify the CoreDNS deployment kubeadm installs.
+	coreDNSNamespace  = "kube-system"
+	coreDNSDeployment = "coredns"
+)
+
+// ErrInvalidReadinessGate is returned when a wait_for entry cannot be parsed.
+//
+//nolint:grouper // false positive
+var ErrInvalidReadinessGate = errors.New("invalid wait_for gate")
+
+// ErrReadinessGatesFailed is returned when wait_for gates are not satisfied in time.
+//
+//nolint:grouper // false positive
+var ErrReadinessGatesFailed = errors.New("wait_for gates not satisfied")
+
+// errNotReady is the cause reported by a gate that is not satisfied yet.
+//
+//nolint:grouper // false positive
+var errNotReady = errors.New("not ready")
+
+// crdResource is the resource of CustomResourceDefinition objects.
+//
+//nolint:gochecknoglobals,grouper // constant resource identifier
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// readinessGates holds the settings of a wait_for block.
+type readinessGates struct {
+	Deployments   []string
+	CRDs          []string
+	AllNodesReady bool
+	CoreDNSReady  bool
+}
+
+// readinessGate is a single named check polled until it passes.
+type readinessGate struct {
+	check func(ctx context.Context) error
+	name  string
+}
+
+// waitForBlock returns the wait_for block schema.
+//
+//nolint:ireturn // schema blocks are returned as interfaces
+func waitForBlock() tfschema.Block {
+	return tfschema.SingleNestedBlock{
+		Description: "Readiness gates polled after creation, within the create timeout.",
+		Attributes: map[string]tfschema.Attribute{
+			"all_nodes_ready": tfschema.BoolAttribute{
+				Optional:    true,
+				Description: "Wait until every node reports Ready.",
+			},
+			"coredns_ready": tfschema.BoolAttribute{
+				Optional:    true,
+				Description: "Wait until the CoreDNS deployment is available. Requires a working CNI.",
+			},
+			"deployments": tfschema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Deployments to wait for, as namespace/name (ex: ingress-nginx/ingress-nginx-controller).",
+			},
+			"crds": tfschema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "CustomResourceDefinitions to wait for until established, by name (ex: certificates.cert-manager.io).",
+			},
+		},
+	}
+}
+
+// flattenReadinessGates converts a wait_for block into readinessGates.
+func flattenReadinessGates(waitFor map[string]any) readinessGates {
+	return readinessGates{
+		AllNodesReady: getBool(waitFor, "all_nodes_ready"),
+		CoreDNSReady:  getBool(waitFor, "coredns_ready"),
+		Deployments:   getStringSlice(waitFor, "deployments"),
+		CRDs:          getStringSlice(waitFor, "crds"),
+	}
+}
+
+// parseDeploymentRef splits a namespace/name deployment reference.
+func parseDeploymentRef(ref string) (string, string, error) {
+	namespace, name, found := strings.Cut(ref, "/")
+	if !found || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("%w: deployment %q must be namespace/name", ErrInvalidReadinessGate, ref)
+	}
+
+	return namespace, name, nil
+}
+
+// gates returns the checks to poll, in a stable order.
+func (g readinessGates) gates(client kubernetes.Interface, dynamicClient dynamic.Interface) []readinessGate {
+	var gates []readinessGate
+
+	if g.AllNodesReady {
+		gates = append(gates, readinessGate{
+			name:  "all_nodes_ready",
+			check: func(ctx context.Context) error { return nodesReady(ctx, client, "") },
+		})
+	}
+
+	if g.CoreDNSReady {
+		gates = append(gates, readinessGate{
+			name: "coredns_ready",
+			check: func(ctx context.Context) error {
+				return deploymentAvailable(ctx, client, coreDNSNamespace, coreDNSDeployment)
+			},
+		})
+	}
+
+	for _, ref := range g.Deployments {
+		gates = append(gates, readinessGate{
+			name: "deployments " + ref,
+			check: func(ctx context.Context) error {
+				namespace, name, err := parseDeploymentRef(ref)
+				if err != nil {
+					return err
+				}
+
+				return deploymentAvailable(ctx, client, namespace, name)
+			},
+		})
+	}
+
+	for _, name := range g.CRDs {
+		gates = append(gates, readinessGate{
+			name:  "crds " + name,
+			check: func(ctx context.Context) error { return crdEstablished(ctx, dynamicClient, name) },
+		})
+	}
+
+	return gates
+}
+
+// controlPlaneReadyGate waits for the control plane nodes, the check kind runs for wait_for_ready.
+func controlPlaneReadyGate(client kubernetes.Interface) readinessGate {
+	return readinessGate{
+		name:  "wait_for_ready",
+		check: func(ctx context.Context) error { return nodesReady(ctx, client, controlPlaneNodeSelector) },
+	}
+}
+
+// waitForReadinessGates polls the gates until all pass or the timeout expires.
+// The error names every gate that was still failing, with its last cause.
+func waitForReadinessGates(ctx context.Context, gates []readinessGate, timeout time.Duration) error {
+	if len(gates) == 0 {
+		return nil
+	}
+
+	failures := make(map[string]error, len(gates))
+
+	err := wait.PollUntilContextTimeout(ctx, apiServerPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, gate := range gates {
+			checkErr := gate.check(ctx)
+			if checkErr != nil {
+				failures[gate.name] = checkErr
+			} else {
+				delete(failures, gate.name)
+			}
+		}
+
+		return len(failures) == 0, nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	messages := make([]string, 0, len(gates))
+
+	for _, gate := range gates {
+		if cause, failing := failures[gate.name]; failing {
+			messages = append(messages, fmt.Sprintf("%s: %v", gate.name, cause))
+		}
+	}
+
+	return fmt.Errorf("%w after %v: %s", ErrReadinessGatesFailed, timeout, strings.Join(messages, "; "))
+}
+
+// nodesReady checks that every node matching the label selector reports the Ready condition.
+func nodesReady(ctx context.Context, client kubernetes.Interface, selector string) error {
+	nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	if len(nodeList.Items) == 0 {
+		return fmt.Errorf("%w: no nodes registered", errNotReady)
+	}
+
+	var notReady []string
+
+	for _, node := range nodeList.Items {
+		ready := false
+
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+
+		if !ready {
+			notReady = append(notReady, node.Name)
+		}
+	}
+
+	if len(notReady) > 0 {
+		return fmt.Errorf("%w: nodes %s", errNotReady, strings.Join(notReady, ", "))
+	}
+
+	return nil
+}
+
+// deploymentAvailable checks that a deployment rolled out and all its replicas are available.
+func deploymentAvailable(ctx context.Context, client kubernetes.Interface, namespace, name string) error {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas < replicas ||
+		deployment.Status.AvailableReplicas < replicas {
+		return fmt.Errorf(
+			"%w: %d of %d replicas available",
+			errNotReady,
+			deployment.Status.AvailableReplicas,
+			replicas,
+		)
+	}
+
+	return nil
+}
+
+// crdEstablished checks that a CustomResourceDefinition reports the Established condition.
+func crdEstablished(ctx context.Context, client dynamic.Interface, name string) error {
+	crd, err := client.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get CRD: %w", err)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil {
+		return fmt.Errorf("failed to read CRD conditions: %w", err)
+	}
+
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]any)
+		if ok && conditionMap["type"] == "Established" && conditionMap["status"] == "True" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: not established", errNotReady)
+}
@@ -0,0 +1,170 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestFlattenReadinessGates(t *testing.T) {
+	gates := flattenReadinessGates(map[string]any{
+		"all_nodes_ready": true,
+		"deployments":     []any{"ingress-nginx/ingress-nginx-controller"},
+		"crds":            []any{"certificates.cert-manager.io"},
+	})
+
+	assert.Equal(t, readinessGates{
+		AllNodesReady: true,
+		Deployments:   []string{"ingress-nginx/ingress-nginx-controller"},
+		CRDs:          []string{"certificates.cert-manager.io"},
+	}, gates)
+	assert.Equal(t, readinessGates{}, flattenReadinessGates(nil))
+}
+
+func TestParseDeploymentRef(t *testing.T) {
+	tests := []struct {
+		ref       string
+		namespace string
+		name      string
+		wantErr   bool
+	}{
+		{ref: "kube-system/coredns", namespace: "kube-system", name: "coredns"},
+		{ref: "coredns", wantErr: true},
+		{ref: "/coredns", wantErr: true},
+		{ref: "kube-system/", wantErr: true},
+		{ref: "a/b/c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			namespace, name, err := parseDeploymentRef(tt.ref)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidReadinessGate)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
+
+func readinessTestNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func readinessTestDeployment(namespace, name string, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: available},
+	}
+}
+
+func readinessTestCRD(name, established string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": name},
+		"status": map[string]any{
+			"conditions": []any{map[string]any{"type": "Established", "status": established}},
+		},
+	}}
+}
+
+func TestWaitForReadinessGates(t *testing.T) {
+	client := fake.NewClientset(
+		readinessTestNode("test-control-plane", corev1.ConditionTrue),
+		readinessTestNode("test-worker", corev1.ConditionTrue),
+		readinessTestDeployment(coreDNSNamespace, coreDNSDeployment, 2),
+		readinessTestDeployment("ingress", "controller", 2),
+	)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(
+		runtime.NewScheme(),
+		readinessTestCRD("certificates.cert-manager.io", "True"),
+	)
+
+	gates := readinessGates{
+		AllNodesReady: true,
+		CoreDNSReady:  true,
+		Deployments:   []string{"ingress/controller"},
+		CRDs:          []string{"certificates.cert-manager.io"},
+	}.gates(client, dynamicClient)
+	require.Len(t, gates, 4)
+
+	require.NoError(t, waitForReadinessGates(t.Context(), gates, time.Second))
+	require.NoError(t, waitForReadinessGates(t.Context(), nil, time.Second), "no gates pass immediately")
+}
+
+func TestWaitForReadinessGates_Failures(t *testing.T) {
+	client := fake.NewClientset(
+		readinessTestNode("test-control-plane", corev1.ConditionTrue),
+		readinessTestNode("test-worker", corev1.ConditionFalse),
+		readinessTestDeployment(coreDNSNamespace, coreDNSDeployment, 0),
+	)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(
+		runtime.NewScheme(),
+		readinessTestCRD("certificates.cert-manager.io", "False"),
+	)
+
+	gates := readinessGates{
+		AllNodesReady: true,
+		CoreDNSReady:  true,
+		Deployments:   []string{"ingress/missing"},
+		CRDs:          []string{"certificates.cert-manager.io"},
+	}.gates(client, dynamicClient)
+
+	err := waitForReadinessGates(t.Context(), gates, 100*time.Millisecond)
+	require.ErrorIs(t, err, ErrReadinessGatesFailed)
+
+	assert.Contains(t, err.Error(), "all_nodes_ready: not ready: nodes test-worker")
+	assert.Contains(t, err.Error(), "coredns_ready: not ready: 0 of 2 replicas available")
+	assert.Contains(t, err.Error(), "deployments ingress/missing")
+	assert.Contains(t, err.Error(), "crds certificates.cert-manager.io: not ready: not established")
+}
+
+func TestControlPlaneReadyGate(t *testing.T) {
+	controlPlane := readinessTestNode("test-control-plane", corev1.ConditionTrue)
+	controlPlane.Labels = map[string]string{controlPlaneNodeSelector: ""}
+
+	client := fake.NewClientset(controlPlane, readinessTestNode("test-worker", corev1.ConditionFalse))
+
+	gate := controlPlaneReadyGate(client)
+	require.NoError(t, gate.check(t.Context()), "workers are not checked")
+
+	empty := controlPlaneReadyGate(fake.NewClientset(readinessTestNode("test-worker", corev1.ConditionTrue)))
+	require.ErrorIs(t, empty.check(t.Context()), errNotReady)
+}
@@ -18,8 +18,12 @@ package kind
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
+	"slices"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -27,11 +31,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 	"sigs.k8s.io/kind/pkg/cluster"
 	"sigs.k8s.io/kind/pkg/cmd"
 )
@@ -48,6 +61,12 @@ const (
 	// kubeProxyModeNone represents the "none" kube-proxy mode.
 	kubeProxyModeNone = "none"
 
+	// Cluster status values reported in the status attribute.
+	clusterStatusRunning  = "running"
+	clusterStatusUnready  = "unready"
+	clusterStatusDegraded = "degraded"
+	clusterStatusStopped  = "stopped"
+
 	// Runtime provider names.
 	providerDocker  = "docker"
 	providerPodman  = "podman"
@@ -56,11 +75,13 @@ const (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &ClusterResource{}
-	_ resource.ResourceWithConfigure   = &ClusterResource{}
-	_ resource.ResourceWithImportState = &ClusterResource{}
+	_ resource.Resource                   = &ClusterResource{}
+	_ resource.ResourceWithConfigure      = &ClusterResource{}
+	_ resource.ResourceWithImportState    = &ClusterResource{}
+	_ resource.ResourceWithModifyPlan     = &ClusterResource{}
+	_ resource.ResourceWithValidateConfig = &ClusterResource{}
 
-	errDeleteTimeout = fmt.Errorf("delete operation timed out after %v", defaultTimeout)
+	errDeleteTimeout = errors.New("delete operation timed out")
 )
 
 // NewClusterResource is a helper function to simplify the provider implementation.
@@ -73,32 +94,69 @@ func NewClusterResource() resource.Resource {
 // ClusterResource is the resource implementation.
 // ClusterResourceModel describes the resource data model.
 type (
-	ClusterResource struct{}
+	ClusterResource struct {
+		// runtime holds the provider-level runtime settings used when the resource sets none
+		runtime runtimeSettings
+	}
 
 	ClusterResourceModel struct {
-		KindConfig           types.List   `tfsdk:"kind_config"`
-		ID                   types.String `tfsdk:"id"`
-		Name                 types.String `tfsdk:"name"`
-		NodeImage            types.String `tfsdk:"node_image"`
-		Runtime              types.String `tfsdk:"runtime"`
-		KubeconfigPath       types.String `tfsdk:"kubeconfig_path"`
-		Kubeconfig           types.String `tfsdk:"kubeconfig"`
-		ClientCertificate    types.String `tfsdk:"client_certificate"`
-		ClientKey            types.String `tfsdk:"client_key"`
-		ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
-		Endpoint             types.String `tfsdk:"endpoint"`
-		WaitForReady         types.Bool   `tfsdk:"wait_for_ready"`
-		Completed            types.Bool   `tfsdk:"completed"`
+		KindConfig                types.List   `tfsdk:"kind_config"`
+		BootstrapManifests        types.List   `tfsdk:"bootstrap_manifests"`
+		TrustedCACertificates     types.List   `tfsdk:"trusted_ca_certificates"`
+		ID                        types.String `tfsdk:"id"`
+		Name                      types.String `tfsdk:"name"`
+		NodeImage                 types.String `tfsdk:"node_image"`
+		Runtime                   types.String `tfsdk:"runtime"`
+		KubeconfigPath            types.String `tfsdk:"kubeconfig_path"`
+		Kubeconfig                types.String `tfsdk:"kubeconfig"`
+		KubeconfigInternal        types.String `tfsdk:"kubeconfig_internal"`
+		ClientCertificate         types.String `tfsdk:"client_certificate"`
+		ClientKey                 types.String `tfsdk:"client_key"`
+		ClusterCACertificate      types.String `tfsdk:"cluster_ca_certificate"`
+		Endpoint                  types.String `tfsdk:"endpoint"`
+		EndpointInternal          types.String `tfsdk:"endpoint_internal"`
+		APIServerEndpointOverride types.String `tfsdk:"api_server_endpoint_override"`
+		DockerHost                types.String `tfsdk:"docker_host"`
+		DockerContext             types.String `tfsdk:"docker_context"`
+		WaitForReady              types.Bool   `tfsdk:"wait_for_ready"`
+		WaitFor                   types.Object `tfsdk:"wait_for"`
+		Completed                 types.Bool   `tfsdk:"completed"`
+		Running                   types.Bool   `tfsdk:"running"`
+		EnsureRunning             types.Bool   `tfsdk:"ensure_running"`
+		Status                    types.String `tfsdk:"status"`
+		Timeouts                  types.Object `tfsdk:"timeouts"`
+		Proxy                     types.Object `tfsdk:"proxy"`
+		Network                   types.Object `tfsdk:"network"`
+		ManifestsChecksum         types.String `tfsdk:"bootstrap_manifests_checksum"`
+		LoadBalancerPoolSize      types.Int64  `tfsdk:"load_balancer_pool_size"`
+		LoadBalancerAddressPool   types.List   `tfsdk:"load_balancer_address_pool"`
+		PublishedPorts            types.List   `tfsdk:"published_ports"`
+		APIServerHostPort         types.Int64  `tfsdk:"api_server_host_port"`
 	}
 )
 
 // Configure adds the provider configured client to the resource.
-func (*ClusterResource) Configure(
+func (clusterResource *ClusterResource) Configure(
 	_ context.Context,
-	_ resource.ConfigureRequest,
-	_ *resource.ConfigureResponse,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
 ) {
-	// Provider has no configuration, so nothing to configure
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	clusterResource.runtime = *settings
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -128,6 +186,17 @@ func (clusterResource *ClusterResource) Create(
 
 	waitForReady := data.WaitForReady.ValueBool()
 	kubeconfigPath := data.KubeconfigPath.ValueString()
+	manifests, _ := manifestSources(data.BootstrapManifests)
+
+	createTimeout, timeoutErr := operationTimeout(data.Timeouts, timeoutCreate)
+	if timeoutErr != nil {
+		resp.Diagnostics.AddError("Invalid timeouts", timeoutErr.Error())
+
+		return
+	}
+
+	// Every wait of the create shares one deadline, so the whole create stays within the timeout
+	deadline := time.Now().Add(createTimeout)
 
 	var copts []cluster.CreateOption
 
@@ -135,43 +204,89 @@ func (clusterResource *ClusterResource) Create(
 		copts = append(copts, cluster.CreateWithKubeconfigPath(kubeconfigPath))
 	}
 
-	// Handle kind_config if provided
-	if !data.KindConfig.IsNull() && len(data.KindConfig.Elements()) > 0 {
-		kindConfig, err := parseKindConfigFromFramework(ctx, data.KindConfig)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error parsing kind_config",
-				"Could not parse kind_config: "+err.Error(),
-			)
+	runtime, runtimeErr := clusterResource.kindRuntime(&data)
+	if runtimeErr != nil {
+		resp.Diagnostics.AddError("Invalid provider", runtimeErr.Error())
 
-			return
-		}
+		return
+	}
 
-		if kindConfig != nil {
-			copts = append(copts, cluster.CreateWithV1Alpha4Config(kindConfig))
+	remoteHost, hostErr := runtime.settings.remoteHost(ctx, runtime.name)
+	if hostErr != nil {
+		resp.Diagnostics.AddError("Error resolving runtime host", hostErr.Error())
+
+		return
+	}
+
+	// Environment problems are not transient, so they fail before the create retries
+	resp.Diagnostics.Append(preflightDiagnostics(runtime.preflight(ctx, remoteHost, nodeImage))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Handle kind_config and the resource-level settings rendered into it
+	kindConfig, configErr := buildKindConfig(ctx, &data, remoteHost)
+	if configErr != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing kind_config",
+			"Could not parse kind_config: "+configErr.Error(),
+		)
+
+		return
+	}
+
+	// Write the trusted CA files the local nodes mount
+	if remoteHost == "" {
+		resp.Diagnostics.Append(writeClusterTrustedCAs(&data)...)
+
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
 	// Always set node image (either user-provided or default)
-	copts = append(copts, cluster.CreateWithNodeImage(nodeImage))
+	copts = append(copts, cluster.CreateWithV1Alpha4Config(kindConfig), cluster.CreateWithNodeImage(nodeImage))
 
-	if waitForReady {
-		copts = append(copts, cluster.CreateWithWaitForReady(defaultTimeout))
+	// kind reads the proxy and network settings from the process environment while it creates the nodes
+	createEnv := make(map[string]string)
+
+	proxy := flattenProxy(data.Proxy)
+	if proxy != nil {
+		maps.Copy(createEnv, proxy.env())
 	}
 
-	provider, provErr := newKindProvider(data.Runtime.ValueString())
-	if provErr != nil {
-		resp.Diagnostics.AddError("Invalid provider", provErr.Error())
+	network := flattenNetwork(data.Network)
+	networkCreated := false
 
-		return
+	if network != nil {
+		var networkErr error
+
+		networkCreated, networkErr = runtime.ensureNetwork(ctx, network)
+		if networkErr != nil {
+			resp.Diagnostics.AddError("Error creating network", networkErr.Error())
+
+			return
+		}
+
+		binary, _ := runtimeBinary(runtime.name)
+		maps.Copy(createEnv, network.env(binary))
 	}
 
+	createRuntime := runtime.withEnv(createEnv)
+
 	// Retry cluster creation for transient failures
-	var err error
+	var (
+		err        error
+		classified classifiedError
+		attempts   int
+	)
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			delErr := provider.Delete(name, "")
+			delErr := runtime.run(func(provider *cluster.Provider) error {
+				return provider.Delete(name, "")
+			})
 			if delErr != nil {
 				tflog.Warn(ctx, fmt.Sprintf("Failed to delete cluster during retry: %v", delErr))
 			}
@@ -179,39 +294,183 @@ func (clusterResource *ClusterResource) Create(
 			time.Sleep(retryDelay)
 		}
 
-		err = provider.Create(name, copts...)
+		attempts++
+
+		attemptOpts := copts
+
+		// Bootstrap manifests may install the CNI the control plane waits for,
+		// so the wait happens after they are applied
+		if waitForReady && len(manifests) == 0 {
+			attemptOpts = append(slices.Clone(copts), cluster.CreateWithWaitForReady(remainingTimeout(deadline)))
+		}
+
+		err = createRuntime.run(func(provider *cluster.Provider) error {
+			return provider.Create(name, attemptOpts...)
+		})
 		if err == nil {
 			break
 		}
+
+		classified = classifyError(err)
+		tflog.Warn(ctx, "Cluster creation attempt failed", map[string]any{
+			"cluster":        name,
+			"attempt":        attempts,
+			"error_category": classified.Category,
+		})
+
+		// Permanent failures would fail the same way on every attempt
+		if classified.Permanent {
+			break
+		}
 	}
 
 	if err != nil {
+		// Do not leave behind a network only this cluster would have used
+		if networkCreated {
+			_, _ = runtime.removeNetworkIfUnused(ctx, network.Name)
+		}
+
+		tflog.Error(ctx, "Cluster creation failed", map[string]any{
+			"cluster":        name,
+			"attempts":       attempts,
+			"error_category": classified.Category,
+		})
+
 		resp.Diagnostics.AddError(
-			"Error creating Kind cluster",
-			fmt.Sprintf(
+			classified.diagnosticSummary("Error creating Kind cluster"),
+			classified.detail(fmt.Sprintf(
 				"Could not create cluster %s after %d attempts: %s",
 				name,
-				maxRetries+1,
+				attempts,
 				err.Error(),
-			),
+			)),
 		)
 
 		return
 	}
 
+	if networkCreated {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, networkCreatedKey, []byte("true"))...)
+	}
+
 	// Set node_image to the actual value used (either user-provided or default)
 	data.NodeImage = types.StringValue(nodeImage)
 
 	// Set ID
 	data.ID = types.StringValue(fmt.Sprintf("%s-%s", name, nodeImage))
 
+	// The cluster exists from here on, so a failing post-create step keeps it in state
+	// and Terraform taints it instead of leaking a live cluster
+	defer func() {
+		if resp.Diagnostics.HasError() {
+			savePartialState(ctx, &data, &resp.State, &resp.Diagnostics)
+		}
+	}()
+
+	// Configure the registry mirrors inside the freshly created nodes
+	clusterResource.configureRegistryMirrors(ctx, runtime, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Add the CA certificates to the system trust store, and write them into remote nodes
+	clusterResource.installTrustedCAs(ctx, runtime, &data, remoteHost, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if proxy != nil && proxy.NoProxy == "" {
+		clusterResource.readNoProxy(ctx, runtime, &data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.LoadBalancerAddressPool = types.ListNull(types.StringType)
+
+	if size := data.LoadBalancerPoolSize.ValueInt64(); size > 0 {
+		networkName := defaultNetworkName
+		if network != nil {
+			networkName = network.Name
+		}
+
+		var ipFamily v1alpha4.ClusterIPFamily
+		if kindConfig != nil {
+			ipFamily = kindConfig.Networking.IPFamily
+		}
+
+		pool, poolErr := runtime.allocateAddressPool(ctx, name, networkName, ipFamily, int(size))
+		if poolErr != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("load_balancer_pool_size"),
+				"Error allocating load balancer address pool",
+				poolErr.Error(),
+			)
+
+			return
+		}
+
+		var poolDiags diag.Diagnostics
+
+		data.LoadBalancerAddressPool, poolDiags = types.ListValueFrom(ctx, types.StringType, pool)
+		resp.Diagnostics.Append(poolDiags...)
+	}
+
 	// Read the cluster state
-	clusterResource.readClusterState(ctx, &data, &resp.Diagnostics)
+	restConfig := clusterResource.readClusterState(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(manifests) > 0 {
+		clusterResource.applyBootstrapManifests(ctx, restConfig, manifests, remainingTimeout(deadline), &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if waitForReady {
+			clusterResource.waitForControlPlane(ctx, restConfig, remainingTimeout(deadline), &resp.Diagnostics)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	// Apply the node taints, kind only applies the labels at creation
+	desired := declaredNodeMetadata(name, kindConfigMapFromFramework(data.KindConfig))
+	clusterResource.reconcileNodeMetadata(ctx, restConfig, desired, nil, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait for the readiness gates before dependent resources run
+	clusterResource.waitForReadinessGates(ctx, restConfig, &data, remainingTimeout(deadline), &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.Status = types.StringValue(clusterStatusRunning)
+
+	// Pause the cluster right away when it is declared as stopped
+	if !data.Running.ValueBool() {
+		data.Status = types.StringValue(clusterStatusStopped)
+
+		err = runtime.stopCluster(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Error stopping Kind cluster", err.Error())
+
+			return
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -242,7 +501,55 @@ func (clusterResource *ClusterResource) Read(
 		return
 	}
 
-	clusterResource.readClusterState(ctx, &data, &resp.Diagnostics)
+	name := data.Name.ValueString()
+
+	runtime, runtimeErr := clusterResource.kindRuntime(&data)
+	if runtimeErr != nil {
+		resp.Diagnostics.AddError("Invalid provider", runtimeErr.Error())
+
+		return
+	}
+
+	running, total, err := runtime.runningNodes(ctx, name)
+	if errors.Is(err, ErrNoClusterNodes) {
+		tflog.Info(ctx, fmt.Sprintf("Cluster %s has no nodes, removing it from state", name))
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Kind cluster",
+			fmt.Sprintf("Could not read container state of cluster %s: %s", name, err.Error()),
+		)
+
+		return
+	}
+
+	// running is the desired state and only reported on import, the observed state is the status
+	if data.Running.IsNull() {
+		data.Running = types.BoolValue(running == total)
+	}
+
+	// Stopped clusters keep their last known connection details
+	if running != total {
+		data.Status = types.StringValue(containerStatus(running, total))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+		return
+	}
+
+	restConfig := clusterResource.readClusterState(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Status = types.StringValue(apiServerStatus(ctx, restConfig))
+
+	// Report drift of the declared node labels and taints
+	clusterResource.readNodeMetadata(ctx, restConfig, &data, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -298,12 +605,30 @@ func (*ClusterResource) Schema(
 				Default:     booldefault.StaticBool(false),
 				Description: "Defines whether or not the provider will wait for the control plane to be ready. Defaults to false.",
 			},
+			"running": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the node containers should run. Setting it to false stops all containers, including the external load balancer; setting it back to true starts them and waits for the APIServer. Containers stopped outside Terraform are reported by status, and only restarted with ensure_running. Defaults to true.",
+			},
+			"ensure_running": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Restart the cluster during apply whenever its status is not 'running', for example after a host reboot, and wait for the APIServer before dependent resources run. Only applies while running is true. Defaults to false.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Observed cluster status: 'running', 'unready' (containers running, APIServer not ready), 'degraded' (some containers stopped) or 'stopped'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"kubeconfig_path": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "Kubeconfig path set after the cluster is created or by the user to override defaults.",
+				Description: "Kubeconfig path set after the cluster is created or by the user to override defaults. Changing it re-exports the kubeconfig in place.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -311,223 +636,1456 @@ func (*ClusterResource) Schema(
 				Computed:    true,
 				Sensitive:   true,
 				Description: "Kubeconfig set after the cluster is created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"client_certificate": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
 				Description: "Client certificate for authenticating to cluster.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"client_key": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
 				Description: "Client key for authenticating to cluster.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"cluster_ca_certificate": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
 				Description: "Client verifies the server certificate with this CA cert.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"endpoint": schema.StringAttribute{
 				Computed:    true,
 				Description: "Kubernetes APIServer endpoint.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_server_host_port": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Port the APIServer is published on on the runtime host, picked by the runtime unless networking.api_server_port is set.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"published_ports": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Ports published by the node containers on the runtime host, including those picked by the runtime for extra_port_mappings without a host_port. Refreshed when the cluster restarts.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node":           schema.StringAttribute{Computed: true, Description: "Name of the node container."},
+						"container_port": schema.Int64Attribute{Computed: true, Description: "Port in the container."},
+						"protocol":       schema.StringAttribute{Computed: true, Description: "Protocol: 'TCP', 'UDP', or 'SCTP'."},
+						"host_port":      schema.Int64Attribute{Computed: true, Description: "Port on the host."},
+						"listen_address": schema.StringAttribute{Computed: true, Description: "Listen address on the host."},
+					},
+				},
+			},
+			"kubeconfig_internal": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Kubeconfig pointing at the internal APIServer endpoint, reachable from containers on the cluster network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"endpoint_internal": schema.StringAttribute{
+				Computed:    true,
+				Description: "Kubernetes APIServer endpoint reachable from containers on the cluster network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"docker_host": schema.StringAttribute{
+				Optional:    true,
+				Description: "Container runtime host for this cluster (ex: ssh://user@build-box). Overrides the provider-level docker_host.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"docker_context": schema.StringAttribute{
+				Optional:    true,
+				Description: "Docker context (or podman connection) for this cluster. Overrides the provider-level docker_context.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"api_server_endpoint_override": schema.StringAttribute{
+				Optional:    true,
+				Description: "Overrides the APIServer endpoint written to kubeconfig (ex: docker, docker:6443 or https://docker:6443). The host is added to the APIServer certificate SANs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"trusted_ca_certificates": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "PEM encoded CA certificates, such as the CA of a TLS-intercepting proxy, mounted into every node before kubeadm runs and installed into the system trust store and the containerd registry configuration. On a remote runtime host they are written into the nodes right after creation and containerd is restarted to pick them up.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"bootstrap_manifests": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Manifests server-side applied right after the cluster is created, as file paths or inline YAML/JSON (ex: a CNI when disable_default_cni is set). Changed manifests are re-applied in place; objects removed from them are not deleted.",
+			},
+			"bootstrap_manifests_checksum": schema.StringAttribute{
+				Computed:    true,
+				Description: "Checksum of the bootstrap manifest contents, changes when a referenced file changes.",
+			},
+			"load_balancer_pool_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of addresses to reserve for LoadBalancer services (ex: with MetalLB), carved from the top of the node network subnet. Between 1 and %d.", maxLoadBalancerPoolSize),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"load_balancer_address_pool": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Address ranges reserved with load_balancer_pool_size, one per IP family in the start-end notation (ex: 172.18.255.240-172.18.255.254). They hold no node address and overlap no pool of another cluster on the network.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"completed": schema.BoolAttribute{
 				Computed:    true,
 				Description: "Cluster successfully created.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
-//
-
-func (*ClusterResource) Update(
-	_ context.Context,
-	_ resource.UpdateRequest,
-	resp *resource.UpdateResponse,
-) {
-	// Kind clusters don't support updates - everything is ForceNew
-	// This method should not be called, but we implement it for completeness
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"Kind clusters do not support updates. All changes require replacement.",
-	)
-}
-
-// Delete deletes the resource and removes the Terraform state on success.
-//
-
-func (*ClusterResource) Delete(
+// ModifyPlan plans the bootstrap manifests checksum, and a start of clusters that are stopped,
+// or not running when ensure_running is set. Only on start the connection details become unknown,
+// so dependent resources wait for it. New clusters get their fixed host ports checked.
+func (clusterResource *ClusterResource) ModifyPlan(
 	ctx context.Context,
-	req resource.DeleteRequest,
-	resp *resource.DeleteResponse,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
 ) {
-	var data ClusterResourceModel
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	var plan ClusterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create a context with timeout for delete operation
-	deleteCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
-	defer cancel()
+	plan.ManifestsChecksum = planManifestsChecksum(plan.BootstrapManifests, &resp.Diagnostics)
 
-	name := data.Name.ValueString()
-	kubeconfigPath := data.KubeconfigPath.ValueString()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	provider, provErr := newKindProvider(data.Runtime.ValueString())
-	if provErr != nil {
-		resp.Diagnostics.AddError("Invalid provider", provErr.Error())
+	// Nothing to restart on create
+	if req.State.Raw.IsNull() {
+		clusterResource.checkHostPorts(ctx, &plan, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 
 		return
 	}
 
-	// Run delete in a goroutine to respect context timeout
-	errChan := make(chan error, 1)
+	var state ClusterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Connection details are kept from state unless the containers are started
+	if !resumeCluster(&plan, &state) {
+		if !plan.Running.ValueBool() && !state.Running.Equal(types.BoolValue(false)) && containersUp(&state) {
+			plan.Status = types.StringValue(clusterStatusStopped)
+		}
+
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Cluster %s is %s, planning a start", plan.Name.ValueString(), state.Status.ValueString()))
+
+	plan.Status = types.StringValue(clusterStatusRunning)
+	plan.Kubeconfig = types.StringUnknown()
+	plan.KubeconfigInternal = types.StringUnknown()
+	plan.ClientCertificate = types.StringUnknown()
+	plan.ClientKey = types.StringUnknown()
+	plan.ClusterCACertificate = types.StringUnknown()
+	plan.Endpoint = types.StringUnknown()
+	plan.EndpointInternal = types.StringUnknown()
+	plan.PublishedPorts = types.ListUnknown(publishedPortType)
+	plan.APIServerHostPort = types.Int64Unknown()
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// ValidateConfig validates the structured kind_config settings at plan time.
+func (*ClusterResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var kindConfig types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("kind_config"), &kindConfig)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKindConfigMap(kindConfigMapFromFramework(kindConfig))...)
+
+	var timeouts types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("timeouts"), &timeouts)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var waitFor types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wait_for"), &waitFor)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, ref := range flattenReadinessGates(objectToMap(waitFor)).Deployments {
+		_, _, err := parseDeploymentRef(ref)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("wait_for").AtName("deployments").AtListIndex(i),
+				"Invalid wait_for",
+				err.Error(),
+			)
+		}
+	}
+
+	var trustedCAs types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("trusted_ca_certificates"), &trustedCAs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, certificate := range trustedCAs.Elements() {
+		value, ok := certificate.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+
+		err := validateCACertificate(value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("trusted_ca_certificates").AtListIndex(i),
+				"Invalid trusted_ca_certificates",
+				err.Error(),
+			)
+		}
+	}
+
+	var proxyBlock types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("proxy"), &proxyBlock)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if proxy := flattenProxy(proxyBlock); proxy != nil && !objectHasUnknown(proxyBlock) {
+		err := proxy.validate()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("proxy"), "Invalid proxy", err.Error())
+		}
+	}
+
+	var networkBlock types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("network"), &networkBlock)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if network := flattenNetwork(networkBlock); network != nil && !objectHasUnknown(networkBlock) {
+		err := network.validate()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("network"), "Invalid network", err.Error())
+		}
+	}
+
+	var poolSize types.Int64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("load_balancer_pool_size"), &poolSize)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !poolSize.IsNull() && !poolSize.IsUnknown() &&
+		(poolSize.ValueInt64() < 1 || poolSize.ValueInt64() > maxLoadBalancerPoolSize) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("load_balancer_pool_size"),
+			"Invalid load_balancer_pool_size",
+			fmt.Sprintf("load_balancer_pool_size must be between 1 and %d.", maxLoadBalancerPoolSize),
+		)
+	}
+
+	var bootstrapManifests types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bootstrap_manifests"), &bootstrapManifests)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if sources, known := manifestSources(bootstrapManifests); known {
+		for i, source := range sources {
+			_, err := loadManifestObjects([]string{source})
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("bootstrap_manifests").AtListIndex(i),
+					"Invalid bootstrap_manifests",
+					err.Error(),
+				)
+			}
+		}
+	}
+
+	for _, operation := range []string{timeoutCreate, timeoutUpdate, timeoutDelete} {
+		_, err := operationTimeout(timeouts, operation)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeouts").AtName(operation),
+				"Invalid timeouts",
+				err.Error(),
+			)
+		}
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+//
+
+func (clusterResource *ClusterResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data, state ClusterResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other attribute requires replacement, so this is only reached on a schema bug
+	if attribute := replaceOnlyChange(&data, &state); attribute != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Update not supported",
+			fmt.Sprintf("Changing %s requires replacing the Kind cluster.", attribute),
+		)
+
+		return
+	}
+
+	runtime, runtimeErr := clusterResource.kindRuntime(&data)
+	if runtimeErr != nil {
+		resp.Diagnostics.AddError("Invalid provider", runtimeErr.Error())
+
+		return
+	}
+
+	resuming := resumeCluster(&data, &state)
+
+	if resuming || !state.Running.Equal(types.BoolValue(false)) && containersUp(&state) {
+		clusterResource.updateRunningCluster(ctx, runtime, &data, &state, resuming, &resp.Diagnostics)
+	} else {
+		updateStoppedCluster(&data, &state, &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// updateRunningCluster applies in-place changes to a cluster that is running or being resumed,
+// and pauses it afterwards when running was set to false.
+func (clusterResource *ClusterResource) updateRunningCluster(
+	ctx context.Context,
+	runtime *kindRuntime,
+	data, state *ClusterResourceModel,
+	resuming bool,
+	diags *diag.Diagnostics,
+) {
+	name := data.Name.ValueString()
+	kubeconfigPath := data.KubeconfigPath.ValueString()
+	previousPath := state.KubeconfigPath.ValueString()
+
+	updateTimeout, err := operationTimeout(data.Timeouts, timeoutUpdate)
+	if err != nil {
+		diags.AddError("Invalid timeouts", err.Error())
+
+		return
+	}
+
+	if resuming {
+		tflog.Info(ctx, "Starting containers of cluster "+name)
+
+		err = runtime.startCluster(ctx, name)
+		if err != nil {
+			diags.AddError("Error starting Kind cluster", err.Error())
+
+			return
+		}
+	}
+
+	// Re-export the kubeconfig when the export path changed, or the ports changed on resume
+	if kubeconfigPath != "" && (kubeconfigPath != previousPath || resuming) {
+		err = runtime.run(func(provider *cluster.Provider) error {
+			return provider.ExportKubeConfig(name, kubeconfigPath, false)
+		})
+		if err != nil {
+			diags.AddError(
+				"Error exporting kubeconfig",
+				fmt.Sprintf("Could not export kubeconfig for cluster %s: %s", name, err.Error()),
+			)
+
+			return
+		}
+
+		if previousPath != "" && previousPath != kubeconfigPath {
+			removeKubeconfigContext(ctx, previousPath, "previous", "kind-"+name)
+		}
+	}
+
+	// Refresh the computed attributes
+	restConfig := clusterResource.readClusterState(ctx, data, diags)
+
+	if diags.HasError() {
+		return
+	}
+
+	if resuming {
+		err = waitForAPIServer(ctx, restConfig, updateTimeout)
+		if err != nil {
+			diags.AddError("Error starting Kind cluster", err.Error())
+
+			return
+		}
+
+		data.Status = types.StringValue(clusterStatusRunning)
+	}
+
+	// Patch the node labels and taints through the Kubernetes API
+	clusterResource.reconcileNodeMetadata(
+		ctx,
+		restConfig,
+		declaredNodeMetadata(name, kindConfigMapFromFramework(data.KindConfig)),
+		declaredNodeMetadata(name, kindConfigMapFromFramework(state.KindConfig)),
+		diags,
+	)
+
+	if diags.HasError() {
+		return
+	}
+
+	// Re-apply the bootstrap manifests when their content changed
+	if !data.ManifestsChecksum.Equal(state.ManifestsChecksum) {
+		manifests, _ := manifestSources(data.BootstrapManifests)
+
+		clusterResource.applyBootstrapManifests(ctx, restConfig, manifests, updateTimeout, diags)
+
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if data.Running.ValueBool() {
+		return
+	}
+
+	tflog.Info(ctx, "Stopping containers of cluster "+name)
+
+	err = runtime.stopCluster(ctx, name)
+	if err != nil {
+		diags.AddError("Error stopping Kind cluster", err.Error())
+
+		return
+	}
+
+	data.Status = types.StringValue(clusterStatusStopped)
+}
+
+// removeClusterNetwork removes the network the cluster created once no other cluster uses it.
+// Networks that existed before, such as the ones of kind_network resources, are left alone.
+func removeClusterNetwork(
+	ctx context.Context,
+	runtime *kindRuntime,
+	network *clusterNetwork,
+	created bool,
+	diags *diag.Diagnostics,
+) {
+	if network == nil || !created {
+		return
+	}
+
+	removed, err := runtime.removeNetworkIfUnused(ctx, network.Name)
+	if err != nil {
+		diags.AddWarning(
+			"Error removing network",
+			fmt.Sprintf("Could not remove network %s: %s", network.Name, err.Error()),
+		)
+	} else if removed {
+		tflog.Info(ctx, "Removed network "+network.Name)
+	}
+}
+
+// savePartialState saves the model of a cluster whose creation failed after the nodes came up.
+// Values the failing step left unknown are saved as null, as state cannot hold unknown values.
+func savePartialState(ctx context.Context, data *ClusterResourceModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	var stateDiags diag.Diagnostics
+
+	stateDiags.Append(state.Set(ctx, data)...)
+
+	if stateDiags.HasError() {
+		diags.Append(stateDiags...)
+
+		return
+	}
+
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !value.IsKnown() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		diags.AddError("Error saving partial cluster state", err.Error())
+
+		return
+	}
+
+	state.Raw = raw
+}
+
+// resumeCluster reports whether the update starts the containers: running is switched back
+// to true, or ensure_running is set and the observed status is not 'running'.
+func resumeCluster(data, state *ClusterResourceModel) bool {
+	if !data.Running.ValueBool() {
+		return false
+	}
+
+	if state.Running.Equal(types.BoolValue(false)) {
+		return true
+	}
+
+	return data.EnsureRunning.ValueBool() && !state.Status.IsNull() && state.Status.ValueString() != clusterStatusRunning
+}
+
+// containersUp reports whether the node containers were observed running, so the cluster
+// can be reached for in-place updates.
+func containersUp(state *ClusterResourceModel) bool {
+	status := state.Status.ValueString()
+
+	return status != clusterStatusStopped && status != clusterStatusDegraded
+}
+
+// updateStoppedCluster applies in-place changes to a cluster that stays stopped, or whose
+// containers were stopped outside Terraform without ensure_running.
+// Only settings that do not need the API server can change, the connection details are kept.
+func updateStoppedCluster(data, state *ClusterResourceModel, diags *diag.Diagnostics) {
+	name := data.Name.ValueString()
+
+	if data.KubeconfigPath.ValueString() != state.KubeconfigPath.ValueString() {
+		diags.AddAttributeError(
+			path.Root("kubeconfig_path"),
+			"Cluster is stopped",
+			"The kubeconfig can only be exported while the cluster is running. Set ensure_running to restart a cluster stopped outside Terraform.",
+		)
+	}
+
+	if !reflect.DeepEqual(
+		declaredNodeMetadata(name, kindConfigMapFromFramework(data.KindConfig)),
+		declaredNodeMetadata(name, kindConfigMapFromFramework(state.KindConfig)),
+	) {
+		diags.AddAttributeError(
+			path.Root("kind_config"),
+			"Cluster is stopped",
+			"Node labels and taints can only be updated while the cluster is running. Set ensure_running to restart a cluster stopped outside Terraform.",
+		)
+	}
+
+	if !data.ManifestsChecksum.Equal(state.ManifestsChecksum) {
+		diags.AddAttributeError(
+			path.Root("bootstrap_manifests"),
+			"Cluster is stopped",
+			"Bootstrap manifests can only be applied while the cluster is running. Set ensure_running to restart a cluster stopped outside Terraform.",
+		)
+	}
+
+	// Stopped clusters keep their last known connection details
+	data.Kubeconfig = state.Kubeconfig
+	data.KubeconfigInternal = state.KubeconfigInternal
+	data.ClientCertificate = state.ClientCertificate
+	data.ClientKey = state.ClientKey
+	data.ClusterCACertificate = state.ClusterCACertificate
+	data.Endpoint = state.Endpoint
+	data.EndpointInternal = state.EndpointInternal
+	data.PublishedPorts = state.PublishedPorts
+	data.APIServerHostPort = state.APIServerHostPort
+	data.Completed = state.Completed
+	data.Status = state.Status
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+//
+
+func (clusterResource *ClusterResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data ClusterResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, timeoutErr := operationTimeout(data.Timeouts, timeoutDelete)
+	if timeoutErr != nil {
+		resp.Diagnostics.AddError("Invalid timeouts", timeoutErr.Error())
+
+		return
+	}
+
+	// Create a context with timeout for delete operation
+	deleteCtx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	name := data.Name.ValueString()
+	kubeconfigPath := data.KubeconfigPath.ValueString()
+
+	runtime, runtimeErr := clusterResource.kindRuntime(&data)
+	if runtimeErr != nil {
+		resp.Diagnostics.AddError("Invalid provider", runtimeErr.Error())
+
+		return
+	}
+
+	// Run delete in a goroutine to respect context timeout
+	errChan := make(chan error, 1)
 
 	go func() {
-		errChan <- provider.Delete(name, kubeconfigPath)
+		errChan <- runtime.run(func(provider *cluster.Provider) error {
+			return provider.Delete(name, kubeconfigPath)
+		})
 	}()
 
-	var err error
+	var err error
+
+	select {
+	case err = <-errChan:
+		// Delete completed
+	case <-deleteCtx.Done():
+		err = fmt.Errorf("%w after %v", errDeleteTimeout, deleteTimeout)
+	}
+
+	if err != nil {
+		classified := classifyError(err)
+		tflog.Error(ctx, "Cluster deletion failed", map[string]any{
+			"cluster":        name,
+			"error_category": classified.Category,
+		})
+
+		resp.Diagnostics.AddError(
+			classified.diagnosticSummary("Error deleting Kind cluster"),
+			classified.detail(fmt.Sprintf("Could not delete cluster %s: %s", name, err.Error())),
+		)
+
+		return
+	}
+
+	networkCreated, privateDiags := req.Private.GetKey(ctx, networkCreatedKey)
+	resp.Diagnostics.Append(privateDiags...)
+
+	removeClusterNetwork(ctx, runtime, flattenNetwork(data.Network), string(networkCreated) == "true", &resp.Diagnostics)
+
+	// Remove the trusted CA files the nodes mounted
+	if dir, dirErr := trustedCADir(name); dirErr == nil {
+		dirErr = os.RemoveAll(dir)
+		if dirErr != nil {
+			tflog.Warn(ctx, "Could not remove the trusted CA files: "+dirErr.Error())
+		}
+	}
+
+	// Remove kubeconfig context, user, and cluster from default kubeconfig
+	contextName := "kind-" + name
+
+	// Clean up default kubeconfig
+	defaultKubeconfigPath := clientcmd.RecommendedHomeFile
+	removeKubeconfigContext(ctx, defaultKubeconfigPath, "default", contextName)
+
+	// Clean up custom kubeconfig if specified
+	if kubeconfigPath != "" {
+		removeKubeconfigContext(ctx, kubeconfigPath, "custom", contextName)
+	}
+}
+
+// ImportState imports the resource state.
+func (*ClusterResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// newKindProvider creates a Kind cluster provider with the specified runtime.
+// If providerName is empty, kind auto-detects the available runtime.
+func newKindProvider(providerName string) (*cluster.Provider, error) {
+	opts := []cluster.ProviderOption{
+		cluster.ProviderWithLogger(cmd.NewLogger()),
+	}
+
+	switch providerName {
+	case "":
+		// Auto-detect
+	case providerDocker:
+		opts = append(opts, cluster.ProviderWithDocker())
+	case providerPodman:
+		opts = append(opts, cluster.ProviderWithPodman())
+	case providerNerdctl:
+		opts = append(opts, cluster.ProviderWithNerdctl(""))
+	default:
+		return nil, fmt.Errorf(
+			"unsupported provider %q: must be one of docker, podman, nerdctl",
+			providerName,
+		)
+	}
+
+	return cluster.NewProvider(opts...), nil
+}
+
+// validateKindConfigMap validates the structured settings of a kind_config block.
+// Unknown values are skipped, they are validated once they become known.
+func validateKindConfigMap(kindConfig map[string]any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if kindConfig == nil {
+		return diags
+	}
+
+	kindConfigPath := path.Root("kind_config").AtListIndex(0)
+
+	err := flattenKubeletConfig(getMap(kindConfig, "kubelet_config")).validate()
+	if err != nil {
+		diags.AddAttributeError(kindConfigPath.AtName("kubelet_config"), "Invalid kubelet_config", err.Error())
+	}
+
+	for i, patch := range getStringSlice(kindConfig, "containerd_config_patches") {
+		_, err = normalizeToml(patch)
+		if err != nil {
+			diags.AddAttributeError(
+				kindConfigPath.AtName("containerd_config_patches").AtListIndex(i),
+				"Invalid containerd_config_patches",
+				err.Error(),
+			)
+		}
+	}
+
+	for i, mirror := range flattenRegistryMirrors(kindConfig) {
+		err = mirror.validate()
+		if err != nil {
+			diags.AddAttributeError(
+				kindConfigPath.AtName("containerd").AtName("registry_mirror").AtListIndex(i),
+				"Invalid registry_mirror",
+				err.Error(),
+			)
+		}
+	}
+
+	for i, node := range getMapSlice(kindConfig, "node") {
+		nodePath := kindConfigPath.AtName("node").AtListIndex(i)
+
+		err = flattenKubeletConfig(getMap(node, "kubelet_config")).validate()
+		if err != nil {
+			diags.AddAttributeError(nodePath.AtName("kubelet_config"), "Invalid kubelet_config", err.Error())
+		}
+
+		for j, taint := range flattenNodeTaints(node) {
+			err = validateTaint(taint)
+			if err != nil {
+				diags.AddAttributeError(nodePath.AtName("taints").AtListIndex(j), "Invalid taint", err.Error())
+			}
+		}
+	}
+
+	return diags
+}
+
+// buildKindConfig assembles the kind cluster configuration from kind_config
+// and the resource-level settings that have to be rendered into it.
+// remoteHost is the address of a remote runtime host, or empty for a local runtime.
+func buildKindConfig(
+	ctx context.Context,
+	data *ClusterResourceModel,
+	remoteHost string,
+) (*v1alpha4.Cluster, error) {
+	kindConfig, err := parseKindConfigFromFramework(ctx, data.KindConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if kindConfig == nil {
+		kindConfig = newDefaultKindConfig()
+	}
+
+	// Let the runtime pick the APIServer host port, like for the extra port mappings
+	if kindConfig.Networking.APIServerPort == 0 {
+		kindConfig.Networking.APIServerPort = runtimePickedHostPort
+	}
+
+	extraSANs := getStringSlice(
+		getMap(kindConfigMapFromFramework(data.KindConfig), "api_server"),
+		"cert_sans",
+	)
+
+	if host := overrideHost(endpointOverride(data, remoteHost)); host != "" {
+		extraSANs = append(extraSANs, host)
+	}
+
+	// The trusted CAs are also set up as the default containerd registry configuration
+	if files := trustedCAFiles(listToStringSlice(data.TrustedCACertificates)); len(files) > 0 {
+		if !slices.Contains(kindConfig.ContainerdConfigPatches, containerdRegistryConfigPatch) {
+			kindConfig.ContainerdConfigPatches = append(kindConfig.ContainerdConfigPatches, containerdRegistryConfigPatch)
+		}
+
+		// Local nodes mount them from the start, remote ones get them written after creation
+		if remoteHost == "" {
+			err = mountTrustedCAs(kindConfig, data.Name.ValueString(), files)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(extraSANs) == 0 {
+		return kindConfig, nil
+	}
+
+	// A remote API server is only reachable when it listens on all interfaces
+	if remoteHost != "" && kindConfig.Networking.APIServerAddress == "" {
+		kindConfig.Networking.APIServerAddress = remoteAPIServerAddress
+		if kindConfig.Networking.IPFamily == v1alpha4.IPv6Family {
+			kindConfig.Networking.APIServerAddress = remoteAPIServerAddressIPv6
+		}
+	}
+
+	patch, err := certSANsPatch(apiServerCertSANs(kindConfig, extraSANs...))
+	if err != nil {
+		return nil, err
+	}
+
+	kindConfig.KubeadmConfigPatches = append(kindConfig.KubeadmConfigPatches, patch)
+
+	return kindConfig, nil
+}
+
+// mountTrustedCAs mounts the trusted CA files into every node of the cluster, including
+// the control-plane node kind creates when kind_config declares none.
+func mountTrustedCAs(kindConfig *v1alpha4.Cluster, clusterName string, files map[string]string) error {
+	dir, err := trustedCADir(clusterName)
+	if err != nil {
+		return err
+	}
+
+	if len(kindConfig.Nodes) == 0 {
+		kindConfig.Nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+	}
+
+	mounts := trustedCAMounts(dir, files)
+
+	for i := range kindConfig.Nodes {
+		kindConfig.Nodes[i].ExtraMounts = append(kindConfig.Nodes[i].ExtraMounts, mounts...)
+	}
+
+	return nil
+}
+
+// endpointOverride returns the APIServer endpoint override for the resource:
+// the explicit api_server_endpoint_override, or the remote runtime host.
+func endpointOverride(data *ClusterResourceModel, remoteHost string) string {
+	if override := data.APIServerEndpointOverride.ValueString(); override != "" {
+		return override
+	}
+
+	return remoteHost
+}
+
+// kindRuntime returns the kind runtime for the resource, using the resource-level
+// runtime settings and falling back to the provider-level ones.
+func (clusterResource *ClusterResource) kindRuntime(data *ClusterResourceModel) (*kindRuntime, error) {
+	settings := runtimeSettings{
+		DockerHost:    data.DockerHost.ValueString(),
+		DockerContext: data.DockerContext.ValueString(),
+	}
+
+	return newKindRuntime(data.Runtime.ValueString(), settings.merge(clusterResource.runtime))
+}
+
+// replaceOnlyChange returns the first attribute that differs between plan and
+// state and cannot be updated in place, or an empty string.
+func replaceOnlyChange(plan, state *ClusterResourceModel) string {
+	changes := []struct {
+		attribute string
+		changed   bool
+	}{
+		{"name", !plan.Name.Equal(state.Name)},
+		{"node_image", !plan.NodeImage.Equal(state.NodeImage)},
+		{"runtime", !plan.Runtime.Equal(state.Runtime)},
+		{"kind_config", !reflect.DeepEqual(kindConfigWithoutNodeMetadata(plan), kindConfigWithoutNodeMetadata(state))},
+		{"docker_host", !plan.DockerHost.Equal(state.DockerHost)},
+		{"docker_context", !plan.DockerContext.Equal(state.DockerContext)},
+		{"api_server_endpoint_override", !plan.APIServerEndpointOverride.Equal(state.APIServerEndpointOverride)},
+		{"trusted_ca_certificates", !plan.TrustedCACertificates.Equal(state.TrustedCACertificates)},
+		{"proxy", !plan.Proxy.Equal(state.Proxy)},
+		{"network", !plan.Network.Equal(state.Network)},
+		{"load_balancer_pool_size", !plan.LoadBalancerPoolSize.Equal(state.LoadBalancerPoolSize)},
+	}
+
+	for _, change := range changes {
+		if change.changed {
+			return change.attribute
+		}
+	}
+
+	return ""
+}
+
+// kindConfigWithoutNodeMetadata returns kind_config without the node labels and taints,
+// which are updated in place through the Kubernetes API.
+func kindConfigWithoutNodeMetadata(data *ClusterResourceModel) map[string]any {
+	kindConfig := kindConfigMapFromFramework(data.KindConfig)
+
+	for _, node := range getMapSlice(kindConfig, "node") {
+		delete(node, "labels")
+		delete(node, "taints")
+	}
+
+	return kindConfig
+}
+
+// newDefaultKindConfig returns an empty kind configuration that kind fills with its defaults.
+func newDefaultKindConfig() *v1alpha4.Cluster {
+	return &v1alpha4.Cluster{
+		TypeMeta: v1alpha4.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "kind.x-k8s.io/v1alpha4",
+		},
+	}
+}
+
+// readNoProxy records the NO_PROXY value kind passed to the nodes, which includes the
+// pod subnet, the service subnet and the node network it appended to the configured hosts.
+func (*ClusterResource) readNoProxy(
+	ctx context.Context,
+	runtime *kindRuntime,
+	data *ClusterResourceModel,
+	diags *diag.Diagnostics,
+) {
+	nodeList, err := runtime.selectClusterNodes(data.Name.ValueString(), string(v1alpha4.ControlPlaneRole), "")
+	if err != nil {
+		diags.AddError("Error reading proxy settings", err.Error())
+
+		return
+	}
+
+	output, err := runtime.containerCommand(ctx,
+		"inspect", "--format", "{{range .Config.Env}}{{println .}}{{end}}", nodeList[0].String())
+	if err != nil {
+		diags.AddError("Error reading proxy settings", err.Error())
+
+		return
+	}
+
+	proxy, proxyDiags := setProxyNoProxy(ctx, data.Proxy, containerEnvValue(output, envNoProxy))
+	diags.Append(proxyDiags...)
+
+	data.Proxy = proxy
+}
+
+// checkHostPorts reports the fixed host ports of a new cluster that are already taken, so
+// clashes surface at plan time rather than as kind errors after the create retries.
+// It is best effort: nothing is reported while the configuration is unknown or the
+// runtime cannot be reached.
+func (clusterResource *ClusterResource) checkHostPorts(
+	ctx context.Context,
+	data *ClusterResourceModel,
+	diags *diag.Diagnostics,
+) {
+	if data.Name.IsUnknown() || data.KindConfig.IsUnknown() || data.Runtime.IsUnknown() ||
+		data.DockerHost.IsUnknown() || data.DockerContext.IsUnknown() ||
+		data.APIServerEndpointOverride.IsUnknown() {
+		return
+	}
+
+	runtime, err := clusterResource.kindRuntime(data)
+	if err != nil {
+		return
+	}
 
-	select {
-	case err = <-errChan:
-		// Delete completed
-	case <-deleteCtx.Done():
-		err = errDeleteTimeout
+	remoteHost, err := runtime.settings.remoteHost(ctx, runtime.name)
+	if err != nil {
+		return
 	}
 
+	kindConfig, err := buildKindConfig(ctx, data, remoteHost)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting Kind cluster",
-			fmt.Sprintf("Could not delete cluster %s: %s", name, err.Error()),
-		)
+		return
+	}
 
+	bindings := declaredHostPorts(kindConfig)
+	if len(bindings) == 0 {
 		return
 	}
 
-	// Remove kubeconfig context, user, and cluster from default kubeconfig
-	contextName := "kind-" + name
+	others, err := runtime.kindClusterPorts(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Skipping the host port check against other clusters: "+err.Error())
+	}
 
-	// Helper function to safely remove context from a kubeconfig
-	removeContext := func(configPath, configType string) {
-		config, loadErr := clientcmd.LoadFromFile(configPath)
-		if loadErr != nil {
-			tflog.Warn(
-				ctx,
-				fmt.Sprintf(
-					"Unable to load %s kubeconfig for context cleanup: %v",
-					configType,
-					loadErr,
-				),
-			)
+	// Ports of a remote runtime host cannot be probed from here
+	probe := probeHostPort
+	if remoteHost != "" {
+		probe = nil
+	}
+
+	reportHostPortConflicts(bindings, data.Name.ValueString(), others, probe, diags)
+}
+
+// installTrustedCAs refreshes the system trust store of every node with the trusted CA
+// certificates. Nodes on a remote runtime host cannot mount them from this machine, so
+// there the files are written after creation and containerd is restarted to pick them up.
+func (*ClusterResource) installTrustedCAs(
+	ctx context.Context,
+	runtime *kindRuntime,
+	data *ClusterResourceModel,
+	remoteHost string,
+	diags *diag.Diagnostics,
+) {
+	files := trustedCAFiles(listToStringSlice(data.TrustedCACertificates))
+	if len(files) == 0 {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	nodeList, err := runtime.internalNodes(name)
+	if err != nil {
+		diags.AddError("Error installing trusted CA certificates", err.Error())
+
+		return
+	}
+
+	commands := [][]string{{"update-ca-certificates"}}
+
+	if remoteHost != "" {
+		tflog.Debug(ctx, fmt.Sprintf("Installing trusted CA certificates into cluster %s", name))
+
+		err = runtime.writeNodeFiles(nodeList, files)
+		if err != nil {
+			diags.AddError("Error installing trusted CA certificates", err.Error())
 
 			return
 		}
 
-		if _, exists := config.Contexts[contextName]; !exists {
+		commands = append(commands, []string{"systemctl", "restart", "containerd"})
+	}
+
+	for _, command := range commands {
+		err = runtime.execInNodesChecked(ctx, nodeList, command...)
+		if err != nil {
+			diags.AddError("Error installing trusted CA certificates", err.Error())
+
 			return
 		}
+	}
+}
 
-		delete(config.Contexts, contextName)
-		delete(config.AuthInfos, contextName)
-		delete(config.Clusters, contextName)
+// writeClusterTrustedCAs writes the trusted CA files the nodes of a local cluster mount.
+func writeClusterTrustedCAs(data *ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-		if config.CurrentContext == contextName {
-			config.CurrentContext = ""
-		}
+	files := trustedCAFiles(listToStringSlice(data.TrustedCACertificates))
+	if len(files) == 0 {
+		return diags
+	}
 
-		writeErr := clientcmd.WriteToFile(*config, configPath)
-		if writeErr != nil {
-			tflog.Warn(
-				ctx,
-				fmt.Sprintf(
-					"Unable to write %s kubeconfig to remove context: %v",
-					configType,
-					writeErr,
-				),
-			)
-		}
+	dir, err := trustedCADir(data.Name.ValueString())
+	if err != nil {
+		diags.AddError("Error installing trusted CA certificates", err.Error())
+
+		return diags
 	}
 
-	// Clean up default kubeconfig
-	defaultKubeconfigPath := clientcmd.RecommendedHomeFile
-	removeContext(defaultKubeconfigPath, "default")
+	err = writeTrustedCAFiles(dir, files)
+	if err != nil {
+		diags.AddError("Error installing trusted CA certificates", err.Error())
+	}
 
-	// Clean up custom kubeconfig if specified
-	if kubeconfigPath != "" {
-		removeContext(kubeconfigPath, "custom")
+	return diags
+}
+
+// configureRegistryMirrors writes the containerd hosts.toml files of the configured
+// registry mirrors into every node of the cluster.
+func (*ClusterResource) configureRegistryMirrors(
+	ctx context.Context,
+	runtime *kindRuntime,
+	data *ClusterResourceModel,
+	diags *diag.Diagnostics,
+) {
+	mirrors := flattenRegistryMirrors(kindConfigMapFromFramework(data.KindConfig))
+	if len(mirrors) == 0 {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	files, err := registryMirrorFiles(mirrors)
+	if err != nil {
+		diags.AddError("Error configuring registry mirrors", err.Error())
+
+		return
+	}
+
+	nodeList, err := runtime.internalNodes(name)
+	if err != nil {
+		diags.AddError("Error configuring registry mirrors", err.Error())
+
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Writing %d registry mirror files into cluster %s", len(files), name))
+
+	err = runtime.writeNodeFiles(nodeList, files)
+	if err != nil {
+		diags.AddError("Error configuring registry mirrors", err.Error())
 	}
 }
 
-// ImportState imports the resource state.
-func (*ClusterResource) ImportState(
+// reconcileNodeMetadata patches the labels and taints of the Node objects.
+// previous holds the labels and taints managed before, nil on create.
+func (*ClusterResource) reconcileNodeMetadata(
 	ctx context.Context,
-	req resource.ImportStateRequest,
-	resp *resource.ImportStateResponse,
+	restConfig *rest.Config,
+	desired, previous map[string]nodeMetadata,
+	diags *diag.Diagnostics,
 ) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if len(desired) == 0 && len(previous) == 0 {
+		return
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		diags.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating labels and taints of %d nodes", len(desired)))
+
+	err = reconcileNodeMetadata(ctx, client, desired, previous)
+	if err != nil {
+		diags.AddError("Error updating node labels and taints", err.Error())
+	}
 }
 
-// newKindProvider creates a Kind cluster provider with the specified runtime.
-// If providerName is empty, kind auto-detects the available runtime.
-func newKindProvider(providerName string) (*cluster.Provider, error) {
-	opts := []cluster.ProviderOption{
-		cluster.ProviderWithLogger(cmd.NewLogger()),
+// waitForReadinessGates polls the wait_for gates within what is left of the create timeout.
+func (*ClusterResource) waitForReadinessGates(
+	ctx context.Context,
+	restConfig *rest.Config,
+	data *ClusterResourceModel,
+	timeout time.Duration,
+	diags *diag.Diagnostics,
+) {
+	if data.WaitFor.IsNull() || data.WaitFor.IsUnknown() {
+		return
 	}
 
-	switch providerName {
-	case "":
-		// Auto-detect
-	case providerDocker:
-		opts = append(opts, cluster.ProviderWithDocker())
-	case providerPodman:
-		opts = append(opts, cluster.ProviderWithPodman())
-	case providerNerdctl:
-		opts = append(opts, cluster.ProviderWithNerdctl(""))
-	default:
-		return nil, fmt.Errorf(
-			"unsupported provider %q: must be one of docker, podman, nerdctl",
-			providerName,
+	gates := flattenReadinessGates(objectToMap(data.WaitFor))
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		diags.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		diags.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	err = waitForReadinessGates(ctx, gates.gates(client, dynamicClient), timeout)
+	if err != nil {
+		diags.AddAttributeError(path.Root("wait_for"), "Cluster not ready", err.Error())
+	}
+}
+
+// applyBootstrapManifests waits for the APIServer and server-side applies the manifests.
+// Every object that fails is reported on the manifest it comes from.
+func (*ClusterResource) applyBootstrapManifests(
+	ctx context.Context,
+	restConfig *rest.Config,
+	manifests []string,
+	timeout time.Duration,
+	diags *diag.Diagnostics,
+) {
+	if len(manifests) == 0 {
+		return
+	}
+
+	objects, err := loadManifestObjects(manifests)
+	if err != nil {
+		diags.AddAttributeError(path.Root("bootstrap_manifests"), "Invalid bootstrap_manifests", err.Error())
+
+		return
+	}
+
+	err = waitForAPIServer(ctx, restConfig, timeout)
+	if err != nil {
+		diags.AddError("Error applying bootstrap manifests", err.Error())
+
+		return
+	}
+
+	dynamicClient, mapper, err := newManifestClients(restConfig)
+	if err != nil {
+		diags.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Applying %d bootstrap objects", len(objects)))
+
+	for _, failure := range applyManifests(ctx, dynamicClient, mapper, objects) {
+		diags.AddAttributeError(
+			path.Root("bootstrap_manifests").AtListIndex(failure.source),
+			"Error applying bootstrap manifest",
+			failure.Error(),
 		)
 	}
+}
 
-	return cluster.NewProvider(opts...), nil
+// waitForControlPlane waits for the control plane nodes to be Ready, in place of kind's
+// wait_for_ready when it had to be deferred until the bootstrap manifests were applied.
+func (*ClusterResource) waitForControlPlane(
+	ctx context.Context,
+	restConfig *rest.Config,
+	timeout time.Duration,
+	diags *diag.Diagnostics,
+) {
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		diags.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	err = waitForReadinessGates(ctx, []readinessGate{controlPlaneReadyGate(client)}, timeout)
+	if err != nil {
+		diags.AddAttributeError(path.Root("wait_for_ready"), "Cluster not ready", err.Error())
+	}
 }
 
-// readClusterState is a helper function to read cluster state.
-func (*ClusterResource) readClusterState(
+// planManifestsChecksum returns the planned bootstrap_manifests_checksum,
+// unknown while some manifests are not known yet.
+func planManifestsChecksum(manifests types.List, diags *diag.Diagnostics) types.String {
+	sources, known := manifestSources(manifests)
+	if !known {
+		return types.StringUnknown()
+	}
+
+	if len(sources) == 0 {
+		return types.StringNull()
+	}
+
+	checksum, err := manifestsChecksum(sources)
+	if err != nil {
+		diags.AddAttributeError(path.Root("bootstrap_manifests"), "Invalid bootstrap_manifests", err.Error())
+
+		return types.StringUnknown()
+	}
+
+	return types.StringValue(checksum)
+}
+
+// readNodeMetadata writes the actual labels and taints of the declared nodes into kind_config.
+// The cluster state is still usable when the Kubernetes API is unreachable, so failures are warnings.
+func (*ClusterResource) readNodeMetadata(
 	ctx context.Context,
+	restConfig *rest.Config,
 	data *ClusterResourceModel,
 	diags *diag.Diagnostics,
 ) {
 	name := data.Name.ValueString()
 
-	provider, provErr := newKindProvider(data.Runtime.ValueString())
-	if provErr != nil {
-		diags.AddError("Invalid provider", provErr.Error())
+	declared := declaredNodeMetadata(name, kindConfigMapFromFramework(data.KindConfig))
+	if len(declared) == 0 {
+		return
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		diags.AddWarning("Unable to read node labels and taints", err.Error())
+
+		return
+	}
+
+	observed, err := observeNodeMetadata(ctx, client, declared)
+	if err != nil {
+		diags.AddWarning("Unable to read node labels and taints", err.Error())
 
 		return
 	}
 
+	kindConfig, listDiags := setObservedNodeMetadata(ctx, data.KindConfig, name, observed)
+	diags.Append(listDiags...)
+
+	data.KindConfig = kindConfig
+}
+
+// containerStatus returns the cluster status for a number of running containers out of total.
+func containerStatus(running, total int) string {
+	switch running {
+	case total:
+		return clusterStatusRunning
+	case 0:
+		return clusterStatusStopped
+	default:
+		return clusterStatusDegraded
+	}
+}
+
+// apiServerStatus returns the cluster status of a cluster whose containers are all running.
+func apiServerStatus(ctx context.Context, restConfig *rest.Config) string {
+	if apiServerReady(ctx, restConfig) {
+		return clusterStatusRunning
+	}
+
+	return clusterStatusUnready
+}
+
+// readClusterState is a helper function to read cluster state.
+// It returns the REST config of the cluster, or nil on error.
+func (clusterResource *ClusterResource) readClusterState(
+	ctx context.Context,
+	data *ClusterResourceModel,
+	diags *diag.Diagnostics,
+) *rest.Config {
+	name := data.Name.ValueString()
+
+	runtime, runtimeErr := clusterResource.kindRuntime(data)
+	if runtimeErr != nil {
+		diags.AddError("Invalid provider", runtimeErr.Error())
+
+		return nil
+	}
+
 	tflog.Debug(ctx, "Reading cluster state for: "+name)
 
-	kconfig, err := provider.KubeConfig(name, false)
+	var kconfig, kconfigInternal string
+
+	err := runtime.run(func(provider *cluster.Provider) error {
+		var kubeconfigErr error
+
+		kconfig, kubeconfigErr = provider.KubeConfig(name, false)
+
+		return kubeconfigErr
+	})
 	if err != nil {
 		diags.AddError(
 			"Error reading Kind cluster",
 			fmt.Sprintf("Could not read kubeconfig for cluster %s: %s", name, err.Error()),
 		)
 
-		return
+		return nil
+	}
+
+	err = runtime.run(func(provider *cluster.Provider) error {
+		var kubeconfigErr error
+
+		kconfigInternal, kubeconfigErr = provider.KubeConfig(name, true)
+
+		return kubeconfigErr
+	})
+	if err != nil {
+		diags.AddError(
+			"Error reading Kind cluster",
+			fmt.Sprintf("Could not read internal kubeconfig for cluster %s: %s", name, err.Error()),
+		)
+
+		return nil
+	}
+
+	remoteHost, err := runtime.settings.remoteHost(ctx, runtime.name)
+	if err != nil {
+		diags.AddError("Error resolving runtime host", err.Error())
+
+		return nil
+	}
+
+	override := endpointOverride(data, remoteHost)
+
+	kconfig, err = rewriteKubeconfigServer(kconfig, override)
+	if err != nil {
+		diags.AddError("Error overriding APIServer endpoint", err.Error())
+
+		return nil
 	}
 
 	data.Kubeconfig = types.StringValue(kconfig)
+	data.KubeconfigInternal = types.StringValue(kconfigInternal)
 
 	// Set kubeconfig_path if not already set
 	if data.KubeconfigPath.IsNull() || data.KubeconfigPath.ValueString() == "" {
@@ -535,35 +2093,69 @@ func (*ClusterResource) readClusterState(
 		if currentPathErr != nil {
 			diags.AddError("Error getting current directory", currentPathErr.Error())
 
-			return
+			return nil
 		}
 
 		exportPath := fmt.Sprintf("%s%s%s-config", currentPath, string(os.PathSeparator), name)
 
-		err = provider.ExportKubeConfig(name, exportPath, false)
+		err = runtime.run(func(provider *cluster.Provider) error {
+			return provider.ExportKubeConfig(name, exportPath, false)
+		})
 		if err != nil {
 			diags.AddError(
 				"Error exporting kubeconfig",
 				fmt.Sprintf("Could not export kubeconfig for cluster %s: %s", name, err.Error()),
 			)
 
-			return
+			return nil
 		}
 
 		data.KubeconfigPath = types.StringValue(exportPath)
 	}
 
+	err = rewriteKubeconfigFileServer(data.KubeconfigPath.ValueString(), "kind-"+name, override)
+	if err != nil {
+		diags.AddError("Error overriding APIServer endpoint", err.Error())
+
+		return nil
+	}
+
 	// Parse kubeconfig to extract connection details
 	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kconfig))
 	if err != nil {
 		diags.AddError("Error parsing kubeconfig", err.Error())
 
-		return
+		return nil
 	}
 
 	data.ClientCertificate = types.StringValue(string(config.CertData))
 	data.ClientKey = types.StringValue(string(config.KeyData))
 	data.ClusterCACertificate = types.StringValue(string(config.CAData))
 	data.Endpoint = types.StringValue(config.Host)
+
+	internalConfig, err := clientcmd.RESTConfigFromKubeConfig([]byte(kconfigInternal))
+	if err != nil {
+		diags.AddError("Error parsing internal kubeconfig", err.Error())
+
+		return nil
+	}
+
+	data.EndpointInternal = types.StringValue(internalConfig.Host)
+
+	// Ports picked by the runtime are only known once the containers run
+	ports, err := runtime.publishedPorts(ctx, name)
+	if err != nil {
+		diags.AddError("Error reading published ports", err.Error())
+
+		return nil
+	}
+
+	var portsDiags diag.Diagnostics
+
+	data.PublishedPorts, portsDiags = publishedPortsValue(ports)
+	diags.Append(portsDiags...)
+	data.APIServerHostPort = types.Int64Value(int64(apiServerHostPort(ports)))
 	data.Completed = types.BoolValue(true)
+
+	return config
 }
@@ -0,0 +1,508 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &ClusterPeeringResource{}
+	_ resource.ResourceWithConfigure      = &ClusterPeeringResource{}
+	_ resource.ResourceWithValidateConfig = &ClusterPeeringResource{}
+	_ resource.ResourceWithModifyPlan     = &ClusterPeeringResource{}
+
+	// peeringRouteType is the element type of the routes attribute.
+	//
+	//nolint:gochecknoglobals // constant attribute type
+	peeringRouteType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"node":        types.StringType,
+		"destination": types.StringType,
+		"gateway":     types.StringType,
+	}}
+)
+
+// NewClusterPeeringResource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewClusterPeeringResource() resource.Resource {
+	return &ClusterPeeringResource{}
+}
+
+// ClusterPeeringResource is the resource implementation.
+// ClusterPeeringResourceModel describes the resource data model.
+type (
+	ClusterPeeringResource struct {
+		// runtime holds the provider-level runtime settings used to reach the node containers
+		runtime runtimeSettings
+	}
+
+	ClusterPeeringResourceModel struct {
+		runtimeSelection
+
+		ID       types.String `tfsdk:"id"`
+		ClusterA types.String `tfsdk:"cluster_a"`
+		ClusterB types.String `tfsdk:"cluster_b"`
+		InSync   types.Bool   `tfsdk:"in_sync"`
+		Routes   types.List   `tfsdk:"routes"`
+	}
+)
+
+// Configure adds the provider configured client to the resource.
+func (peeringResource *ClusterPeeringResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	peeringResource.runtime = *settings
+}
+
+// Metadata returns the resource type name.
+func (*ClusterPeeringResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_peering"
+}
+
+// Schema defines the schema for the resource.
+func (*ClusterPeeringResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the peering.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"cluster_a": schema.StringAttribute{
+			Required:    true,
+			Description: "Name of the first Kind cluster.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"cluster_b": schema.StringAttribute{
+			Required:    true,
+			Description: "Name of the second Kind cluster.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"in_sync": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether every route is installed. Set to false on refresh when a route is missing (ex: after a node restart), which plans reinstalling them.",
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"routes": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Routes installed in the nodes of both clusters.",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"node":        schema.StringAttribute{Computed: true, Description: "Name of the node container the route is installed in."},
+					"destination": schema.StringAttribute{Computed: true, Description: "Pod CIDR of a node of the peer cluster."},
+					"gateway":     schema.StringAttribute{Computed: true, Description: "Address of that node."},
+				},
+			},
+		},
+	}
+
+	maps.Copy(attributes, runtimeSelectionAttributes())
+
+	resp.Schema = schema.Schema{
+		Description: "Routes the pod CIDRs of two Kind clusters on the same container network to each other, by installing a route to every pod CIDR of one cluster, via the address of its node, in every node of the other. The pod and service subnets of both clusters must not overlap.",
+		Attributes:  attributes,
+	}
+}
+
+// ValidateConfig checks that a cluster is not peered with itself.
+func (*ClusterPeeringResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data ClusterPeeringResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ClusterA.IsUnknown() && !data.ClusterB.IsUnknown() && data.ClusterA.Equal(data.ClusterB) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cluster_b"),
+			"Invalid cluster peering",
+			"A cluster cannot be peered with itself.",
+		)
+	}
+}
+
+// ModifyPlan plans reinstalling the routes when a refresh found some missing.
+func (*ClusterPeeringResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to reinstall on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ClusterPeeringResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.InSync.ValueBool() {
+		return
+	}
+
+	plan.InSync = types.BoolValue(true)
+	plan.Routes = types.ListUnknown(peeringRouteType)
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create validates the subnets of both clusters and installs the routes.
+func (peeringResource *ClusterPeeringResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data ClusterPeeringResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peeringResource.install(ctx, &data, nil, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterA.ValueString() + "/" + data.ClusterB.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks that every route is still installed.
+func (peeringResource *ClusterPeeringResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data ClusterPeeringResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := data.kindRuntime(peeringResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read cluster peering", err.Error())
+
+		return
+	}
+
+	// A cluster without nodes may also be one the runtime cannot see, so the peering is only
+	// dropped once the runtime confirms a cluster is gone
+	first, second, err := peerClusters(ctx, runtime, &data)
+	if errors.Is(err, ErrNoMatchingNodes) && peeredClusterGone(runtime, &data) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read cluster peering", err.Error())
+
+		return
+	}
+
+	expected := clusterPeeringRoutes(first, second)
+
+	missing, err := runtime.missingPeeringRoutes(ctx, slices.Concat(first.Nodes, second.Nodes), expected)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read cluster peering", err.Error())
+
+		return
+	}
+
+	// Node addresses or pod CIDRs change when nodes are recreated, the routes then differ too
+	inSync := len(missing) == 0 && slices.Equal(expected, peeringRoutesFromValue(data.Routes))
+	if !inSync {
+		tflog.Info(ctx, fmt.Sprintf("Cluster peering %s is missing %d routes", data.ID.ValueString(), len(missing)))
+	}
+
+	data.InSync = types.BoolValue(inSync)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update reinstalls the routes and removes those that are no longer needed.
+func (peeringResource *ClusterPeeringResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data, state ClusterPeeringResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peeringResource.install(ctx, &data, peeringRoutesFromValue(state.Routes), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the routes from the nodes that still exist.
+func (peeringResource *ClusterPeeringResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data ClusterPeeringResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := data.kindRuntime(peeringResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	var nodeList []nodes.Node
+
+	for _, name := range []string{data.ClusterA.ValueString(), data.ClusterB.ValueString()} {
+		clusterNodes, listErr := runtime.internalNodes(name)
+		if listErr != nil {
+			resp.Diagnostics.AddError("Error deleting cluster peering", listErr.Error())
+
+			return
+		}
+
+		nodeList = append(nodeList, clusterNodes...)
+	}
+
+	err = runtime.applyPeeringRoutes(ctx, nodeList, "del", peeringRoutesFromValue(data.Routes))
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting cluster peering", err.Error())
+	}
+}
+
+// install validates the networks and subnets of both clusters, installs the routes between them and
+// removes the previous routes that are no longer needed.
+func (peeringResource *ClusterPeeringResource) install(
+	ctx context.Context,
+	data *ClusterPeeringResourceModel,
+	previous []peeringRoute,
+	diags *diag.Diagnostics,
+) {
+	runtime, err := data.kindRuntime(peeringResource.runtime)
+	if err != nil {
+		diags.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	first, second, err := peerClusters(ctx, runtime, data)
+	if err != nil {
+		diags.AddError("Error reading peered clusters", err.Error())
+
+		return
+	}
+
+	err = sharedNetwork(first, second)
+	if err != nil {
+		diags.AddAttributeError(path.Root("cluster_b"), "Invalid cluster peering", err.Error())
+
+		return
+	}
+
+	err = overlappingSubnets(first, second)
+	if err != nil {
+		diags.AddAttributeError(path.Root("cluster_b"), "Invalid cluster peering", err.Error())
+
+		return
+	}
+
+	nodeList := slices.Concat(first.Nodes, second.Nodes)
+	routes := clusterPeeringRoutes(first, second)
+
+	stale := slices.DeleteFunc(slices.Clone(previous), func(route peeringRoute) bool {
+		return slices.Contains(routes, route)
+	})
+
+	tflog.Info(ctx, fmt.Sprintf("Installing %d routes between clusters %s and %s", len(routes), first.Name, second.Name))
+
+	err = runtime.applyPeeringRoutes(ctx, nodeList, "del", stale)
+	if err == nil {
+		err = runtime.applyPeeringRoutes(ctx, nodeList, "replace", routes)
+	}
+
+	if err != nil {
+		diags.AddError("Error installing routes", err.Error())
+
+		return
+	}
+
+	var listDiags diag.Diagnostics
+
+	data.Routes, listDiags = peeringRoutesValue(routes)
+	diags.Append(listDiags...)
+
+	data.InSync = types.BoolValue(true)
+}
+
+// peerClusters reads both clusters of the peering.
+func peerClusters(
+	ctx context.Context,
+	runtime *kindRuntime,
+	data *ClusterPeeringResourceModel,
+) (*peerCluster, *peerCluster, error) {
+	first, err := runtime.peerClusterInfo(ctx, data.ClusterA.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	second, err := runtime.peerClusterInfo(ctx, data.ClusterB.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return first, second, nil
+}
+
+// peeredClusterGone reports whether the runtime lists its clusters without one of the peered clusters.
+func peeredClusterGone(runtime *kindRuntime, data *ClusterPeeringResourceModel) bool {
+	for _, name := range []string{data.ClusterA.ValueString(), data.ClusterB.ValueString()} {
+		exists, err := runtime.clusterExists(name)
+		if err == nil && !exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// peeringRoutesValue builds the routes attribute.
+func peeringRoutesValue(routes []peeringRoute) (types.List, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(routes))
+
+	var diags diag.Diagnostics
+
+	for _, route := range routes {
+		element, objectDiags := types.ObjectValue(peeringRouteType.AttrTypes, map[string]attr.Value{
+			"node":        types.StringValue(route.Node),
+			"destination": types.StringValue(route.Destination),
+			"gateway":     types.StringValue(route.Gateway),
+		})
+		diags.Append(objectDiags...)
+
+		elements = append(elements, element)
+	}
+
+	list, listDiags := types.ListValue(peeringRouteType, elements)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
+
+// peeringRoutesFromValue reads the routes attribute.
+func peeringRoutesFromValue(list types.List) []peeringRoute {
+	var routes []peeringRoute
+
+	for _, element := range listToSlice(list) {
+		route, ok := element.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		routes = append(routes, peeringRoute{
+			Node:        getString(route, "node"),
+			Destination: getString(route, "destination"),
+			Gateway:     getString(route, "gateway"),
+		})
+	}
+
+	return routes
+}
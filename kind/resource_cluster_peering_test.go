@@ -0,0 +1,124 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterPeeringResource_Schema(t *testing.T) {
+	metadata := &resource.MetadataResponse{}
+	(&ClusterPeeringResource{}).Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "kind"}, metadata)
+	assert.Equal(t, "kind_cluster_peering", metadata.TypeName)
+
+	resp := &resource.SchemaResponse{}
+	(&ClusterPeeringResource{}).Schema(t.Context(), resource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["cluster_a"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["routes"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["runtime"].IsOptional())
+}
+
+func TestClusterPeeringResource_ValidateConfig(t *testing.T) {
+	config := func(clusterA, clusterB any) tfsdk.Config {
+		return resourceTestConfig(t, &ClusterPeeringResource{}, map[string]tftypes.Value{
+			"cluster_a": tftypes.NewValue(tftypes.String, clusterA),
+			"cluster_b": tftypes.NewValue(tftypes.String, clusterB),
+		})
+	}
+
+	tests := []struct {
+		clusterA any
+		clusterB any
+		name     string
+		wantErr  bool
+	}{
+		{name: "two clusters", clusterA: "east", clusterB: "west"},
+		{name: "unknown cluster", clusterA: "east", clusterB: tftypes.UnknownValue},
+		{name: "same cluster", clusterA: "east", clusterB: "east", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateResp := &resource.ValidateConfigResponse{}
+			(&ClusterPeeringResource{}).ValidateConfig(
+				t.Context(),
+				resource.ValidateConfigRequest{Config: config(tt.clusterA, tt.clusterB)},
+				validateResp,
+			)
+
+			assert.Equal(t, tt.wantErr, validateResp.Diagnostics.HasError(), validateResp.Diagnostics)
+		})
+	}
+}
+
+func TestPeeringRoutesValue(t *testing.T) {
+	routes := []peeringRoute{
+		{Node: "east-worker", Destination: "10.244.0.0/24", Gateway: "172.18.0.2"},
+		{Node: "west-worker", Destination: "fd00:10:245::/64", Gateway: "fc00:f853:ccd:e793::4"},
+	}
+
+	list, diags := peeringRoutesValue(routes)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, routes, peeringRoutesFromValue(list))
+	assert.Empty(t, peeringRoutesFromValue(types.ListNull(peeringRouteType)))
+}
+
+func TestPeeredClusterGone(t *testing.T) {
+	data := &ClusterPeeringResourceModel{ClusterA: types.StringValue("east"), ClusterB: types.StringValue("west")}
+
+	tests := []struct {
+		name     string
+		clusters string
+		gone     bool
+	}{
+		{name: "both clusters exist", clusters: "east\nwest\n"},
+		{name: "deleted cluster", clusters: "east\n", gone: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, _ := fakeRuntimeCLI(t, map[string]string{"ps -a": tt.clusters})
+			t.Setenv("PATH", filepath.Dir(fake.name)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			runtime := &kindRuntime{name: providerDocker, env: map[string]string{}}
+
+			assert.Equal(t, tt.gone, peeredClusterGone(runtime, data))
+		})
+	}
+
+	t.Run("unreachable runtime", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		runtime := &kindRuntime{name: providerDocker, env: map[string]string{}}
+
+		assert.False(t, peeredClusterGone(runtime, data), "clusters that cannot be listed are not taken as gone")
+	})
+}
@@ -18,9 +18,20 @@ package kind
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/sebdah/goldie/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -125,8 +136,420 @@ func TestClusterResource_Schema_HasRuntimeAttribute(t *testing.T) {
 	assert.False(t, attr.IsComputed(), "runtime should not be computed")
 }
 
+// inPlaceAttributes lists the attributes the cluster resource updates in place.
+var inPlaceAttributes = map[string]bool{
+	"wait_for_ready":                 true,
+	"running":                        true,
+	"wait_for":                       true,
+	"wait_for.all_nodes_ready":       true,
+	"wait_for.coredns_ready":         true,
+	"wait_for.deployments":           true,
+	"wait_for.crds":                  true,
+	"ensure_running":                 true,
+	"bootstrap_manifests":            true,
+	"kubeconfig_path":                true,
+	"timeouts":                       true,
+	"timeouts.create":                true,
+	"timeouts.update":                true,
+	"timeouts.delete":                true,
+	"kind_config.node.labels":        true,
+	"kind_config.node.taints":        true,
+	"kind_config.node.taints.key":    true,
+	"kind_config.node.taints.value":  true,
+	"kind_config.node.taints.effect": true,
+}
+
+// keptComputedAttributes lists the computed attributes kept from state on update,
+// ModifyPlan only marks them unknown when the cluster is started.
+var keptComputedAttributes = map[string]bool{
+	"status":                 true,
+	"completed":              true,
+	"kubeconfig":             true,
+	"kubeconfig_internal":    true,
+	"endpoint":               true,
+	"endpoint_internal":      true,
+	"client_certificate":     true,
+	"client_key":             true,
+	"cluster_ca_certificate": true,
+	"published_ports":        true,
+	"api_server_host_port":   true,
+}
+
+// TestClusterResource_Schema_UpdateClassification ensures every configurable
+// attribute either updates in place or requires replacement, so no plan can
+// reach the Update error path, and that the connection details are kept from state.
+func TestClusterResource_Schema_UpdateClassification(t *testing.T) {
+	r := &ClusterResource{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(t.Context(), resource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+
+	replaceDescription := stringplanmodifier.RequiresReplace().Description(t.Context())
+	keepDescription := stringplanmodifier.UseStateForUnknown().Description(t.Context())
+
+	checked := 0
+	seen := make(map[string]bool)
+	check := func(name string, configurable bool, modifiers []planmodifier.Describer) {
+		seen[name] = true
+
+		if keptComputedAttributes[name] {
+			kept := false
+			for _, modifier := range modifiers {
+				kept = kept || modifier.Description(t.Context()) == keepDescription
+			}
+
+			assert.True(t, kept, "%s should be kept from state with UseStateForUnknown", name)
+		}
+
+		if !configurable || inPlaceAttributes[name] {
+			return
+		}
+
+		checked++
+
+		for _, modifier := range modifiers {
+			description := modifier.Description(t.Context())
+			if description == replaceDescription || description == blockCountChangeDescription {
+				return
+			}
+		}
+
+		t.Errorf("%s is neither updated in place nor marked RequiresReplace", name)
+	}
+
+	var walkAttributes func(prefix string, attrs map[string]schema.Attribute)
+
+	var walkBlocks func(prefix string, blocks map[string]schema.Block)
+
+	walkAttributes = func(prefix string, attrs map[string]schema.Attribute) {
+		for name, attr := range attrs {
+			name = prefix + name
+			configurable := attr.IsOptional() || attr.IsRequired()
+
+			switch a := attr.(type) {
+			case schema.StringAttribute:
+				check(name, configurable, describers(a.PlanModifiers))
+			case schema.BoolAttribute:
+				check(name, configurable, describers(a.PlanModifiers))
+			case schema.Int64Attribute:
+				check(name, configurable, describers(a.PlanModifiers))
+			case schema.MapAttribute:
+				check(name, configurable, describers(a.PlanModifiers))
+			case schema.ListAttribute:
+				check(name, configurable, describers(a.PlanModifiers))
+			case schema.ListNestedAttribute:
+				check(name, configurable, describers(a.PlanModifiers))
+				walkAttributes(name+".", a.NestedObject.Attributes)
+			default:
+				t.Errorf("%s has unhandled attribute type %T", name, attr)
+			}
+		}
+	}
+
+	walkBlocks = func(prefix string, blocks map[string]schema.Block) {
+		for name, block := range blocks {
+			name = prefix + name
+
+			switch b := block.(type) {
+			case schema.ListNestedBlock:
+				check(name, true, describers(b.PlanModifiers))
+				walkAttributes(name+".", b.NestedObject.Attributes)
+				walkBlocks(name+".", b.NestedObject.Blocks)
+			case schema.SingleNestedBlock:
+				check(name, true, describers(b.PlanModifiers))
+				walkAttributes(name+".", b.Attributes)
+				walkBlocks(name+".", b.Blocks)
+			default:
+				t.Errorf("%s has unhandled block type %T", name, block)
+			}
+		}
+	}
+
+	walkAttributes("", resp.Schema.Attributes)
+	walkBlocks("", resp.Schema.Blocks)
+
+	assert.Positive(t, checked, "schema walk should visit replace-only attributes")
+
+	for name := range inPlaceAttributes {
+		assert.True(t, seen[name], "in-place attribute %s should exist in the schema", name)
+	}
+
+	for name := range keptComputedAttributes {
+		assert.True(t, seen[name], "computed attribute %s should exist in the schema", name)
+	}
+}
+
+// describers converts typed plan modifiers into their common Describer interface.
+func describers[T planmodifier.Describer](modifiers []T) []planmodifier.Describer {
+	result := make([]planmodifier.Describer, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		result = append(result, modifier)
+	}
+
+	return result
+}
+
+func TestReplaceOnlyChange(t *testing.T) {
+	state := &ClusterResourceModel{
+		Name:                      types.StringValue("test"),
+		NodeImage:                 types.StringValue("kindest/node:v1.34.0"),
+		Runtime:                   types.StringNull(),
+		KindConfig:                types.ListNull(types.StringType),
+		DockerHost:                types.StringNull(),
+		DockerContext:             types.StringNull(),
+		APIServerEndpointOverride: types.StringNull(),
+		TrustedCACertificates:     types.ListNull(types.StringType),
+		WaitForReady:              types.BoolValue(false),
+		KubeconfigPath:            types.StringValue("/tmp/old"),
+	}
+
+	plan := *state
+	plan.WaitForReady = types.BoolValue(true)
+	plan.KubeconfigPath = types.StringValue("/tmp/new")
+	assert.Empty(t, replaceOnlyChange(&plan, state), "in-place changes should not require replacement")
+
+	plan.DockerHost = types.StringValue("ssh://build-box")
+	assert.Equal(t, "docker_host", replaceOnlyChange(&plan, state))
+}
+
+func TestContainerStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		running  int
+		total    int
+	}{
+		{name: "all containers running", running: 3, total: 3, expected: clusterStatusRunning},
+		{name: "no containers running", running: 0, total: 3, expected: clusterStatusStopped},
+		{name: "some containers running", running: 1, total: 3, expected: clusterStatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, containerStatus(tt.running, tt.total))
+		})
+	}
+}
+
+func TestResumeCluster(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		stateRunning  bool
+		planRunning   bool
+		ensureRunning bool
+		resume        bool
+		up            bool
+	}{
+		{name: "running", status: clusterStatusRunning, stateRunning: true, planRunning: true, up: true},
+		{name: "stopped outside terraform", status: clusterStatusStopped, stateRunning: true, planRunning: true},
+		{
+			name: "stopped outside terraform with ensure_running", status: clusterStatusStopped,
+			stateRunning: true, planRunning: true, ensureRunning: true, resume: true,
+		},
+		{
+			name: "degraded with ensure_running", status: clusterStatusDegraded,
+			stateRunning: true, planRunning: true, ensureRunning: true, resume: true,
+		},
+		{name: "unready", status: clusterStatusUnready, stateRunning: true, planRunning: true, up: true},
+		{name: "started again", status: clusterStatusStopped, planRunning: true, resume: true},
+		{name: "stays stopped", status: clusterStatusStopped, ensureRunning: true},
+		{name: "stopping", status: clusterStatusRunning, stateRunning: true, up: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &ClusterResourceModel{
+				Running: types.BoolValue(tt.stateRunning),
+				Status:  types.StringValue(tt.status),
+			}
+			plan := &ClusterResourceModel{
+				Running:       types.BoolValue(tt.planRunning),
+				EnsureRunning: types.BoolValue(tt.ensureRunning),
+			}
+
+			assert.Equal(t, tt.resume, resumeCluster(plan, state))
+			assert.Equal(t, tt.up, containersUp(state))
+		})
+	}
+}
+
+func TestSavePartialState(t *testing.T) {
+	ctx := t.Context()
+	resp := &resource.SchemaResponse{}
+	NewClusterResource().Schema(ctx, resource.SchemaRequest{}, resp)
+
+	// A freshly created cluster still has every computed attribute unknown
+	objectType, ok := resp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, tftypes.UnknownValue)
+	}
+
+	plan := tfsdk.Plan{Schema: resp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+	var data ClusterResourceModel
+	require.False(t, plan.Get(ctx, &data).HasError())
+
+	data.ID = types.StringValue("test-" + defaultNodeImage)
+	data.Name = types.StringValue("test")
+
+	state := tfsdk.State{Schema: resp.Schema}
+
+	var diags diag.Diagnostics
+
+	savePartialState(ctx, &data, &state, &diags)
+	require.False(t, diags.HasError())
+	assert.True(t, state.Raw.IsFullyKnown())
+
+	var id, kubeconfig types.String
+	require.False(t, state.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	require.False(t, state.GetAttribute(ctx, path.Root("kubeconfig"), &kubeconfig).HasError())
+	assert.Equal(t, "test-"+defaultNodeImage, id.ValueString())
+	assert.True(t, kubeconfig.IsNull())
+}
+
+func TestClusterResource_ReadDeletedCluster(t *testing.T) {
+	fake, _ := fakeRuntimeCLI(t, map[string]string{"ps -a": ""})
+	t.Setenv("PATH", filepath.Dir(fake.name)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	clusterResource := &ClusterResource{}
+	config := resourceTestConfig(t, clusterResource, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, "deleted"),
+		"runtime": tftypes.NewValue(tftypes.String, providerDocker),
+	})
+
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	clusterResource.Read(t.Context(), resource.ReadRequest{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull(), "clusters without nodes are removed from state")
+}
+
+func TestBuildKindConfig_APIServerPort(t *testing.T) {
+	networkingType := types.ObjectType{AttrTypes: map[string]attr.Type{"api_server_port": types.Int64Type}}
+	configType := types.ObjectType{AttrTypes: map[string]attr.Type{"networking": types.ListType{ElemType: networkingType}}}
+
+	withPort := func(port int64) types.List {
+		networking := types.ObjectValueMust(networkingType.AttrTypes, map[string]attr.Value{
+			"api_server_port": types.Int64Value(port),
+		})
+
+		return types.ListValueMust(configType, []attr.Value{
+			types.ObjectValueMust(configType.AttrTypes, map[string]attr.Value{
+				"networking": types.ListValueMust(networkingType, []attr.Value{networking}),
+			}),
+		})
+	}
+
+	tests := []struct {
+		name       string
+		kindConfig types.List
+		want       int32
+	}{
+		{name: "picked by the runtime", kindConfig: types.ListNull(configType), want: runtimePickedHostPort},
+		{name: "unset in kind_config", kindConfig: withPort(0), want: runtimePickedHostPort},
+		{name: "fixed host port", kindConfig: withPort(6443), want: 6443},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &ClusterResourceModel{
+				KindConfig:            tt.kindConfig,
+				TrustedCACertificates: types.ListNull(types.StringType),
+			}
+
+			kindConfig, err := buildKindConfig(t.Context(), data, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, kindConfig.Networking.APIServerPort)
+		})
+	}
+}
+
 func TestProviderConstants(t *testing.T) {
 	assert.Equal(t, "docker", providerDocker)
 	assert.Equal(t, "podman", providerPodman)
 	assert.Equal(t, "nerdctl", providerNerdctl)
 }
+
+func TestClusterResource_Configure(t *testing.T) {
+	r := &ClusterResource{}
+
+	resp := &resource.ConfigureResponse{}
+	r.Configure(t.Context(), resource.ConfigureRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "nil provider data should be accepted")
+
+	settings := &runtimeSettings{DockerHost: "ssh://build-box"}
+	r.Configure(t.Context(), resource.ConfigureRequest{ProviderData: settings}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, *settings, r.runtime)
+
+	resp = &resource.ConfigureResponse{}
+	r.Configure(t.Context(), resource.ConfigureRequest{ProviderData: "unexpected"}, resp)
+	assert.True(t, resp.Diagnostics.HasError(), "unexpected provider data should be rejected")
+}
+
+func TestValidateKindConfigMap(t *testing.T) {
+	assert.False(t, validateKindConfigMap(nil).HasError(), "unset kind_config is valid")
+
+	valid := map[string]any{
+		"kubelet_config": map[string]any{"max_pods": 50},
+		"node": []any{
+			map[string]any{"kubelet_config": map[string]any{"max_pods": 20}},
+		},
+	}
+	assert.False(t, validateKindConfigMap(valid).HasError())
+
+	invalid := map[string]any{
+		"kubelet_config": map[string]any{"image_gc_high_threshold_percent": 150},
+		"node": []any{
+			map[string]any{},
+			map[string]any{"kubelet_config": map[string]any{"max_pods": -1}},
+		},
+	}
+
+	diags := validateKindConfigMap(invalid)
+	require.Equal(t, 2, diags.ErrorsCount(), "cluster and node errors should both be reported")
+
+	paths := make([]string, 0, len(diags))
+	for _, d := range diags {
+		withPath, ok := d.(interface{ Path() path.Path })
+		require.True(t, ok, "diagnostics should carry an attribute path")
+
+		paths = append(paths, withPath.Path().String())
+	}
+
+	assert.ElementsMatch(t, []string{
+		"kind_config[0].kubelet_config",
+		"kind_config[0].node[1].kubelet_config",
+	}, paths)
+}
+
+func TestValidateKindConfigMap_Containerd(t *testing.T) {
+	valid := map[string]any{
+		"containerd_config_patches": []any{"[plugins.cri]\n  sandbox_image = \"test\""},
+		"containerd": map[string]any{
+			"registry_mirror": []any{
+				map[string]any{"host": "docker.io", "endpoints": []any{"http://kind-registry:5000"}},
+			},
+		},
+	}
+	assert.False(t, validateKindConfigMap(valid).HasError())
+
+	invalid := map[string]any{
+		"containerd_config_patches": []any{"[plugins.cri]\n  sandbox_image = \"test\"", "[plugins.cri\n"},
+		"containerd": map[string]any{
+			"registry_mirror": []any{
+				map[string]any{"host": "docker.io", "endpoints": []any{"kind-registry:5000"}},
+			},
+		},
+	}
+
+	diags := validateKindConfigMap(invalid)
+	require.Equal(t, 2, diags.ErrorsCount(), "TOML and mirror errors should both be reported")
+	assert.Equal(t, "Invalid containerd_config_patches", diags[0].Summary())
+	assert.Equal(t, "Invalid registry_mirror", diags[1].Summary())
+}
@@ -0,0 +1,685 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	runtimeschema "k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &ManifestResource{}
+	_ resource.ResourceWithConfigure      = &ManifestResource{}
+	_ resource.ResourceWithModifyPlan     = &ManifestResource{}
+	_ resource.ResourceWithValidateConfig = &ManifestResource{}
+
+	// manifestObjectType is the element type of the objects attribute.
+	//
+	//nolint:gochecknoglobals // constant attribute type
+	manifestObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"api_version": types.StringType,
+		"kind":        types.StringType,
+		"namespace":   types.StringType,
+		"name":        types.StringType,
+		"synced":      types.BoolType,
+	}}
+)
+
+// NewManifestResource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewManifestResource() resource.Resource {
+	return &ManifestResource{}
+}
+
+// ManifestResource is the resource implementation.
+// ManifestResourceModel describes the resource data model.
+type (
+	ManifestResource struct {
+		// runtime holds the provider-level runtime settings used to look up clusters by name
+		runtime runtimeSettings
+	}
+
+	ManifestResourceModel struct {
+		runtimeSelection
+
+		ID           types.String `tfsdk:"id"`
+		ClusterName  types.String `tfsdk:"cluster_name"`
+		Kubeconfig   types.String `tfsdk:"kubeconfig"`
+		YAMLBody     types.String `tfsdk:"yaml_body"`
+		FieldManager types.String `tfsdk:"field_manager"`
+		Objects      types.List   `tfsdk:"objects"`
+	}
+)
+
+// Configure adds the provider configured client to the resource.
+func (manifestResource *ManifestResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	manifestResource.runtime = *settings
+}
+
+// Metadata returns the resource type name.
+func (*ManifestResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_manifest"
+}
+
+// Schema defines the schema for the resource.
+func (*ManifestResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the manifest resource.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"cluster_name": schema.StringAttribute{
+			Optional:    true,
+			Description: "Name of the Kind cluster, reached through runtime, docker_host and docker_context. Conflicts with kubeconfig.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"kubeconfig": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Kubeconfig of the cluster, usually kind_cluster.kubeconfig. Referencing it re-applies the manifest when the cluster is replaced, as changes replace the resource. Conflicts with cluster_name.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"yaml_body": schema.StringAttribute{
+			Required:    true,
+			Description: "Multi-document YAML (or JSON) manifest to apply.",
+		},
+		"field_manager": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(manifestFieldManager),
+			Description: "Server-side apply field manager. Defaults to " + manifestFieldManager + ". Changes replace the resource, so the objects are not left owned by the previous manager.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"objects": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Objects applied from the manifest. synced turns false when an object was changed or deleted outside Terraform, which re-applies it.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"api_version": schema.StringAttribute{Computed: true, Description: "API version of the object."},
+					"kind":        schema.StringAttribute{Computed: true, Description: "Kind of the object."},
+					"namespace":   schema.StringAttribute{Computed: true, Description: "Namespace of the object, as declared."},
+					"name":        schema.StringAttribute{Computed: true, Description: "Name of the object."},
+					"synced":      schema.BoolAttribute{Computed: true, Description: "Whether the live object matches the manifest."},
+				},
+			},
+		},
+	}
+
+	maps.Copy(attributes, runtimeSelectionAttributes())
+
+	resp.Schema = schema.Schema{
+		Description: "Server-side applies YAML manifests into a Kind cluster, pruning objects removed from them.",
+		Attributes:  attributes,
+	}
+}
+
+// ValidateConfig checks that the cluster is set exactly once and that the manifest decodes.
+func (*ManifestResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data ManifestResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values are set at apply time, typically from a kind_cluster created in the same run
+	if !data.ClusterName.IsUnknown() && !data.Kubeconfig.IsUnknown() &&
+		data.ClusterName.IsNull() == data.Kubeconfig.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cluster_name"),
+			"Invalid cluster",
+			"Exactly one of cluster_name or kubeconfig must be set.",
+		)
+	}
+
+	if data.YAMLBody.IsUnknown() || data.YAMLBody.IsNull() {
+		return
+	}
+
+	objects, err := decodeManifest(data.YAMLBody.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml_body"), "Invalid yaml_body", err.Error())
+
+		return
+	}
+
+	seen := make(map[string]bool, len(objects))
+
+	for _, object := range objects {
+		key := manifestObjectKey(object)
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("yaml_body"),
+				"Invalid yaml_body",
+				manifestObjectName(object)+" is declared more than once.",
+			)
+		}
+
+		seen[key] = true
+	}
+}
+
+// ModifyPlan plans the objects of the manifest, all in sync after apply.
+func (*ManifestResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ManifestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.YAMLBody.IsUnknown() {
+		plan.Objects = types.ListUnknown(manifestObjectType)
+	} else {
+		objects, err := loadManifestObjects([]string{plan.YAMLBody.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("yaml_body"), "Invalid yaml_body", err.Error())
+
+			return
+		}
+
+		var listDiags diag.Diagnostics
+
+		plan.Objects, listDiags = manifestObjectsValue(objects, nil)
+		resp.Diagnostics.Append(listDiags...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create applies the manifest and sets the initial Terraform state.
+func (manifestResource *ManifestResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data ManifestResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash := sha256.Sum256([]byte(data.YAMLBody.ValueString()))
+	data.ID = types.StringValue(hex.EncodeToString(hash[:8]))
+
+	manifestResource.apply(ctx, &data, nil, &resp.Diagnostics)
+
+	// The state is saved even on partial failures, so applied objects are pruned later
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the Terraform state with the sync status of every object.
+// The state is kept when the cluster is unreachable, for example while it is stopped,
+// unless readGoneCluster finds that the cluster no longer exists.
+func (manifestResource *ManifestResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data ManifestResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objects, err := loadManifestObjects([]string{data.YAMLBody.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read manifest objects", err.Error())
+
+		return
+	}
+
+	restConfig, err := manifestResource.restConfig(ctx, &data)
+	if err != nil {
+		if manifestResource.readGoneCluster(ctx, &data, objects, err, resp) {
+			return
+		}
+
+		resp.Diagnostics.AddWarning("Unable to read manifest objects", err.Error())
+
+		return
+	}
+
+	client, mapper, err := newManifestClients(restConfig)
+	if err != nil {
+		if manifestResource.readGoneCluster(ctx, &data, objects, err, resp) {
+			return
+		}
+
+		resp.Diagnostics.AddWarning("Unable to read manifest objects", err.Error())
+
+		return
+	}
+
+	synced := make(map[string]bool, len(objects))
+
+	for _, item := range objects {
+		inSync, syncErr := manifestObjectInSync(ctx, client, mapper, item.object, data.FieldManager.ValueString())
+		if syncErr != nil {
+			if manifestResource.readGoneCluster(ctx, &data, objects, syncErr, resp) {
+				return
+			}
+
+			resp.Diagnostics.AddWarning("Unable to read manifest objects", syncErr.Error())
+
+			return
+		}
+
+		synced[manifestObjectKey(item.object)] = inSync
+	}
+
+	var listDiags diag.Diagnostics
+
+	data.Objects, listDiags = manifestObjectsValue(objects, synced)
+	resp.Diagnostics.Append(listDiags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update re-applies the manifest and prunes the objects removed from it.
+func (manifestResource *ManifestResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data, state ManifestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+
+	manifestResource.apply(ctx, &data, &state, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes every object of the manifest.
+func (manifestResource *ManifestResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data ManifestResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restConfig, err := manifestResource.restConfig(ctx, &data)
+	if err != nil {
+		if manifestResource.clusterGone(&data, err) {
+			tflog.Info(ctx, "Cluster of the manifest no longer exists, nothing to delete")
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Error deleting manifest objects", err.Error())
+
+		return
+	}
+
+	client, mapper, err := newManifestClients(restConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	// Delete in reverse apply order, so namespaces and CRDs go last
+	tracked := trackedManifestObjects(data.Objects)
+	slices.Reverse(tracked)
+
+	for _, object := range tracked {
+		err = deleteManifestObject(ctx, client, mapper, object)
+		if err != nil {
+			if manifestResource.clusterGone(&data, err) {
+				tflog.Info(ctx, "Cluster of the manifest no longer exists, nothing to delete")
+
+				return
+			}
+
+			resp.Diagnostics.AddError("Error deleting manifest object", err.Error())
+		}
+	}
+}
+
+// readGoneCluster handles a read error from a cluster that no longer exists and reports whether it did.
+// The resource is removed when the runtime confirms the cluster is gone. When only the kubeconfig is
+// known, the objects are marked out of sync instead, as a stopped cluster refuses connections as well.
+func (manifestResource *ManifestResource) readGoneCluster(
+	ctx context.Context,
+	data *ManifestResourceModel,
+	objects []manifestObject,
+	err error,
+	resp *resource.ReadResponse,
+) bool {
+	if !manifestResource.clusterGone(data, err) {
+		return false
+	}
+
+	if data.ClusterName.ValueString() != "" {
+		resp.State.RemoveResource(ctx)
+
+		return true
+	}
+
+	var listDiags diag.Diagnostics
+
+	data.Objects, listDiags = manifestObjectsValue(objects, map[string]bool{})
+	resp.Diagnostics.Append(listDiags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+
+	return true
+}
+
+// clusterGone reports whether err comes from a cluster that no longer exists, leaving nothing
+// to delete. Clusters referenced by name are looked up through the runtime, while an APIServer
+// refusing connections is taken as a destroyed cluster when only a kubeconfig is known.
+func (manifestResource *ManifestResource) clusterGone(data *ManifestResourceModel, err error) bool {
+	name := data.ClusterName.ValueString()
+	if name == "" {
+		return utilnet.IsConnectionRefused(err)
+	}
+
+	runtime, runtimeErr := data.kindRuntime(manifestResource.runtime)
+	if runtimeErr != nil {
+		return false
+	}
+
+	exists, existsErr := runtime.clusterExists(name)
+
+	return existsErr == nil && !exists
+}
+
+// apply server-side applies the manifest, then prunes the objects of the previous state
+// that are no longer declared. previous is nil on create.
+func (manifestResource *ManifestResource) apply(
+	ctx context.Context,
+	data, previous *ManifestResourceModel,
+	diags *diag.Diagnostics,
+) {
+	objects, err := loadManifestObjects([]string{data.YAMLBody.ValueString()})
+	if err != nil {
+		diags.AddAttributeError(path.Root("yaml_body"), "Invalid yaml_body", err.Error())
+
+		return
+	}
+
+	synced := make(map[string]bool, len(objects))
+	declared := make(map[string]bool, len(objects))
+
+	for _, item := range objects {
+		declared[manifestObjectKey(item.object)] = true
+	}
+
+	// Objects are reported out of sync until they are applied
+	var listDiags diag.Diagnostics
+
+	data.Objects, listDiags = manifestObjectsValue(objects, synced)
+	diags.Append(listDiags...)
+
+	restConfig, err := manifestResource.restConfig(ctx, data)
+	if err != nil {
+		diags.AddError("Error applying manifest", err.Error())
+
+		return
+	}
+
+	client, mapper, err := newManifestClients(restConfig)
+	if err != nil {
+		diags.AddError("Error creating Kubernetes client", err.Error())
+
+		return
+	}
+
+	fieldManager := data.FieldManager.ValueString()
+
+	tflog.Info(ctx, fmt.Sprintf("Applying %d manifest objects", len(objects)))
+
+	for _, item := range objects {
+		err = applyManifestObject(ctx, client, mapper, item.object, fieldManager)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("yaml_body"),
+				"Error applying manifest object",
+				manifestObjectName(item.object)+": "+err.Error(),
+			)
+
+			continue
+		}
+
+		synced[manifestObjectKey(item.object)] = true
+	}
+
+	data.Objects, listDiags = manifestObjectsValue(objects, synced)
+	diags.Append(listDiags...)
+
+	if previous == nil {
+		return
+	}
+
+	// Prune the objects removed from the manifest, in reverse apply order
+	pruned := trackedManifestObjects(previous.Objects)
+	slices.Reverse(pruned)
+
+	for _, object := range pruned {
+		if declared[manifestObjectKey(object)] {
+			continue
+		}
+
+		tflog.Info(ctx, "Pruning "+manifestObjectName(object))
+
+		err = deleteManifestObject(ctx, client, mapper, object)
+		if err != nil {
+			diags.AddError("Error pruning manifest object", err.Error())
+		}
+	}
+}
+
+// restConfig returns the client configuration of the target cluster, parsed from
+// kubeconfig or read from the container runtime by cluster name.
+func (manifestResource *ManifestResource) restConfig(
+	ctx context.Context,
+	data *ManifestResourceModel,
+) (*rest.Config, error) {
+	if !data.Kubeconfig.IsNull() && data.Kubeconfig.ValueString() != "" {
+		config, err := clientcmd.RESTConfigFromKubeConfig([]byte(data.Kubeconfig.ValueString()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+		}
+
+		return config, nil
+	}
+
+	name := data.ClusterName.ValueString()
+
+	runtime, err := data.kindRuntime(manifestResource.runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	var kconfig string
+
+	err = runtime.run(func(provider *cluster.Provider) error {
+		var kubeconfigErr error
+
+		kconfig, kubeconfigErr = provider.KubeConfig(name, false)
+
+		return kubeconfigErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig of cluster %s: %w", name, err)
+	}
+
+	remoteHost, err := runtime.settings.remoteHost(ctx, runtime.name)
+	if err != nil {
+		return nil, err
+	}
+
+	kconfig, err = rewriteKubeconfigServer(kconfig, remoteHost)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kconfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	return config, nil
+}
+
+// manifestObjectKey identifies an object across API versions.
+func manifestObjectKey(object *unstructured.Unstructured) string {
+	return object.GroupVersionKind().GroupKind().String() + "/" + object.GetNamespace() + "/" + object.GetName()
+}
+
+// manifestObjectsValue builds the objects attribute. synced holds the sync status by
+// object key; a nil map marks every object as synced, as planned.
+func manifestObjectsValue(objects []manifestObject, synced map[string]bool) (types.List, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(objects))
+
+	var diags diag.Diagnostics
+
+	for _, item := range objects {
+		inSync := synced == nil || synced[manifestObjectKey(item.object)]
+
+		element, objectDiags := types.ObjectValue(manifestObjectType.AttrTypes, map[string]attr.Value{
+			"api_version": types.StringValue(item.object.GetAPIVersion()),
+			"kind":        types.StringValue(item.object.GetKind()),
+			"namespace":   types.StringValue(item.object.GetNamespace()),
+			"name":        types.StringValue(item.object.GetName()),
+			"synced":      types.BoolValue(inSync),
+		})
+		diags.Append(objectDiags...)
+
+		elements = append(elements, element)
+	}
+
+	list, listDiags := types.ListValue(manifestObjectType, elements)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
+
+// trackedManifestObjects returns the objects recorded in state, with only their identity set.
+func trackedManifestObjects(objects types.List) []*unstructured.Unstructured {
+	tracked := make([]*unstructured.Unstructured, 0, len(objects.Elements()))
+
+	for _, item := range listToSlice(objects) {
+		objectMap, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		object := &unstructured.Unstructured{}
+		object.SetGroupVersionKind(runtimeschema.FromAPIVersionAndKind(getString(objectMap, "api_version"), getString(objectMap, "kind")))
+		object.SetNamespace(getString(objectMap, "namespace"))
+		object.SetName(getString(objectMap, "name"))
+
+		tracked = append(tracked, object)
+	}
+
+	return tracked
+}
@@ -0,0 +1,230 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestResource_Metadata(t *testing.T) {
+	resp := &resource.MetadataResponse{}
+	(&ManifestResource{}).Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "kind"}, resp)
+
+	assert.Equal(t, "kind_manifest", resp.TypeName)
+}
+
+func TestManifestResource_Schema(t *testing.T) {
+	resp := &resource.SchemaResponse{}
+	(&ManifestResource{}).Schema(t.Context(), resource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["yaml_body"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["kubeconfig"].IsSensitive())
+	assert.True(t, resp.Schema.Attributes["objects"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["runtime"].IsOptional())
+
+	for _, name := range []string{"kubeconfig", "field_manager"} {
+		attribute, ok := resp.Schema.Attributes[name].(schema.StringAttribute)
+		require.True(t, ok, name)
+		assert.NotEmpty(t, attribute.PlanModifiers, "%s changes replace the resource", name)
+	}
+}
+
+func TestManifestResource_ValidateConfig(t *testing.T) {
+	body := tftypes.NewValue(tftypes.String, manifestTestDaemonSet)
+
+	tests := []struct {
+		values  map[string]tftypes.Value
+		name    string
+		wantErr bool
+	}{
+		{
+			name:   "cluster name",
+			values: map[string]tftypes.Value{"cluster_name": tftypes.NewValue(tftypes.String, "test"), "yaml_body": body},
+		},
+		{
+			name: "unknown kubeconfig",
+			values: map[string]tftypes.Value{
+				"kubeconfig": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"yaml_body":  body,
+			},
+		},
+		{
+			name:    "no cluster",
+			values:  map[string]tftypes.Value{"yaml_body": body},
+			wantErr: true,
+		},
+		{
+			name: "both cluster name and kubeconfig",
+			values: map[string]tftypes.Value{
+				"cluster_name": tftypes.NewValue(tftypes.String, "test"),
+				"kubeconfig":   tftypes.NewValue(tftypes.String, "apiVersion: v1"),
+				"yaml_body":    body,
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate object",
+			values: map[string]tftypes.Value{
+				"cluster_name": tftypes.NewValue(tftypes.String, "test"),
+				"yaml_body":    tftypes.NewValue(tftypes.String, manifestTestNamespace+"---\n"+manifestTestNamespace),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid yaml",
+			values: map[string]tftypes.Value{
+				"cluster_name": tftypes.NewValue(tftypes.String, "test"),
+				"yaml_body":    tftypes.NewValue(tftypes.String, "kind: Namespace\n"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			req := resource.ValidateConfigRequest{Config: resourceTestConfig(t, &ManifestResource{}, tt.values)}
+
+			(&ManifestResource{}).ValidateConfig(t.Context(), req, resp)
+
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestManifestObjectsValue(t *testing.T) {
+	objects, err := loadManifestObjects([]string{manifestTestDaemonSet, manifestTestNamespace})
+	require.NoError(t, err)
+
+	planned, diags := manifestObjectsValue(objects, nil)
+	require.False(t, diags.HasError())
+
+	for _, element := range listToSlice(planned) {
+		assert.Equal(t, true, element.(map[string]any)["synced"], "planned objects are synced")
+	}
+
+	refreshed, diags := manifestObjectsValue(objects, map[string]bool{manifestObjectKey(objects[0].object): true})
+	require.False(t, diags.HasError())
+	assert.False(t, planned.Equal(refreshed), "unsynced objects show up as a change")
+
+	tracked := trackedManifestObjects(refreshed)
+	require.Len(t, tracked, len(objects))
+
+	for i, object := range tracked {
+		assert.Equal(t, manifestObjectKey(objects[i].object), manifestObjectKey(object))
+		assert.Equal(t, manifestObjectName(objects[i].object), manifestObjectName(object))
+	}
+}
+
+func TestManifestResource_ClusterGone(t *testing.T) {
+	refused := &url.Error{Op: "Delete", URL: "https://127.0.0.1:6443", Err: &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+	}}
+
+	tests := []struct {
+		err         error
+		name        string
+		clusterName string
+		clusters    string
+		gone        bool
+	}{
+		{name: "kubeconfig refusing connections", err: refused, gone: true},
+		{name: "kubeconfig with other errors", err: errors.New("forbidden")},
+		{name: "deleted cluster", clusterName: "east", err: refused, gone: true},
+		{name: "existing cluster", clusterName: "east", clusters: "east\n", err: refused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime, _ := fakeRuntimeCLI(t, map[string]string{"ps -a": tt.clusters})
+			t.Setenv("PATH", filepath.Dir(runtime.name)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			data := &ManifestResourceModel{ClusterName: types.StringNull()}
+			if tt.clusterName != "" {
+				data.ClusterName = types.StringValue(tt.clusterName)
+			}
+
+			assert.Equal(t, tt.gone, (&ManifestResource{}).clusterGone(data, tt.err))
+		})
+	}
+}
+
+func TestManifestResource_ReadGoneCluster(t *testing.T) {
+	refused := &url.Error{Op: "Get", URL: "https://127.0.0.1:6443", Err: &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+	}}
+
+	objects, err := loadManifestObjects([]string{manifestTestNamespace})
+	require.NoError(t, err)
+
+	fake, _ := fakeRuntimeCLI(t, map[string]string{"ps -a": "west\n"})
+	t.Setenv("PATH", filepath.Dir(fake.name)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	read := func(t *testing.T, values map[string]tftypes.Value) *resource.ReadResponse {
+		t.Helper()
+
+		config := resourceTestConfig(t, &ManifestResource{}, values)
+		state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+
+		var data ManifestResourceModel
+		require.False(t, state.Get(t.Context(), &data).HasError())
+
+		resp := &resource.ReadResponse{State: state}
+		assert.True(t, (&ManifestResource{}).readGoneCluster(t.Context(), &data, objects, refused, resp))
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+
+		return resp
+	}
+
+	t.Run("deleted cluster", func(t *testing.T) {
+		resp := read(t, map[string]tftypes.Value{"cluster_name": tftypes.NewValue(tftypes.String, "east")})
+
+		assert.True(t, resp.State.Raw.IsNull(), "the resource is removed")
+	})
+
+	t.Run("unreachable kubeconfig", func(t *testing.T) {
+		resp := read(t, map[string]tftypes.Value{"kubeconfig": tftypes.NewValue(tftypes.String, "apiVersion: v1")})
+
+		var data ManifestResourceModel
+		require.False(t, resp.State.Get(t.Context(), &data).HasError())
+
+		assert.Equal(t, []any{map[string]any{
+			"api_version": "v1", "kind": "Namespace", "namespace": "", "name": "cni", "synced": false,
+		}}, listToSlice(data.Objects), "the objects are marked out of sync")
+	})
+}
@@ -0,0 +1,368 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &NetworkResource{}
+	_ resource.ResourceWithConfigure      = &NetworkResource{}
+	_ resource.ResourceWithValidateConfig = &NetworkResource{}
+	_ resource.ResourceWithImportState    = &NetworkResource{}
+)
+
+// NewNetworkResource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewNetworkResource() resource.Resource {
+	return &NetworkResource{}
+}
+
+// NetworkResource is the resource implementation.
+// NetworkResourceModel describes the resource data model.
+type (
+	NetworkResource struct {
+		// runtime holds the provider-level runtime settings used to reach the container runtime
+		runtime runtimeSettings
+	}
+
+	NetworkResourceModel struct {
+		ID          types.String `tfsdk:"id"`
+		Name        types.String `tfsdk:"name"`
+		Runtime     types.String `tfsdk:"runtime"`
+		IPv4Subnet  types.String `tfsdk:"ipv4_subnet"`
+		IPv4Gateway types.String `tfsdk:"ipv4_gateway"`
+		IPv6Subnet  types.String `tfsdk:"ipv6_subnet"`
+		IPv6Gateway types.String `tfsdk:"ipv6_gateway"`
+		MTU         types.Int64  `tfsdk:"mtu"`
+	}
+)
+
+// Configure adds the provider configured client to the resource.
+func (networkResource *NetworkResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	networkResource.runtime = *settings
+}
+
+// Metadata returns the resource type name.
+func (*NetworkResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+// Schema defines the schema for the resource.
+func (*NetworkResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a docker, podman or nerdctl bridge network that Kind clusters can be placed on with their network block.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the network in the container runtime.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"runtime": schema.StringAttribute{
+				Optional:    true,
+				Description: "Container runtime provider: 'docker', 'podman', or 'nerdctl'. Auto-detected if not set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv4_subnet": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "IPv4 subnet of the network (ex: 172.30.0.0/16). Assigned by the runtime when not set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv4_gateway": schema.StringAttribute{
+				Computed:    true,
+				Description: "IPv4 gateway of the network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv6_subnet": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "IPv6 subnet of the network, enables IPv6 (ex: fc00:f853:ccd:e793::/64).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv6_gateway": schema.StringAttribute{
+				Computed:    true,
+				Description: "IPv6 gateway of the network, empty when IPv6 is not enabled.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mtu": schema.Int64Attribute{
+				Optional:    true,
+				Description: "MTU of the network. Defaults to the runtime default.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the subnets and the MTU.
+func (*NetworkResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data NetworkResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.IsUnknown() || data.IPv4Subnet.IsUnknown() || data.IPv6Subnet.IsUnknown() || data.MTU.IsUnknown() {
+		return
+	}
+
+	err := networkFromModel(&data).validate()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid network", err.Error())
+	}
+}
+
+// Create creates the network and records the subnets the runtime assigned.
+func (networkResource *NetworkResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data NetworkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := newKindRuntime(data.Runtime.ValueString(), networkResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	binary, err := runtimeBinary(runtime.name)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	network := networkFromModel(&data)
+
+	tflog.Info(ctx, "Creating network "+network.Name)
+
+	_, err = runtime.containerCommand(ctx, network.createArgs(binary)...)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating network", err.Error())
+
+		return
+	}
+
+	info, err := runtime.inspectNetwork(ctx, network.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading network", err.Error())
+
+		return
+	}
+
+	setNetworkModel(&data, info)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the subnets and gateways, and removes the resource when the network is gone.
+func (networkResource *NetworkResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data NetworkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := newKindRuntime(data.Runtime.ValueString(), networkResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read network", err.Error())
+
+		return
+	}
+
+	info, err := runtime.inspectNetwork(ctx, data.Name.ValueString())
+	if errors.Is(err, ErrNetworkNotFound) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read network", err.Error())
+
+		return
+	}
+
+	setNetworkModel(&data, info)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never reached with a change, every attribute requires replacement.
+func (*NetworkResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data NetworkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the network, which fails while containers are still attached to it.
+func (networkResource *NetworkResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data NetworkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := newKindRuntime(data.Runtime.ValueString(), networkResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	exists, err := runtime.networkExists(ctx, name)
+	if err == nil && exists {
+		_, err = runtime.containerCommand(ctx, "network", "rm", name)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting network", fmt.Sprintf("Could not delete network %s: %s", name, err.Error()))
+	}
+}
+
+// ImportState imports a network by name.
+func (*NetworkResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// networkFromModel returns the network settings of the resource.
+func networkFromModel(data *NetworkResourceModel) *clusterNetwork {
+	return &clusterNetwork{
+		Name:       data.Name.ValueString(),
+		IPv4Subnet: data.IPv4Subnet.ValueString(),
+		IPv6Subnet: data.IPv6Subnet.ValueString(),
+		MTU:        int(data.MTU.ValueInt64()),
+	}
+}
+
+// setNetworkModel records the ID, subnets and gateways of the network.
+func setNetworkModel(data *NetworkResourceModel, info *networkInfo) {
+	ipv4 := info.subnet(false)
+	ipv6 := info.subnet(true)
+
+	data.ID = types.StringValue(info.ID)
+	data.IPv4Subnet = types.StringValue(ipv4.Subnet)
+	data.IPv4Gateway = types.StringValue(ipv4.Gateway)
+	data.IPv6Subnet = types.StringValue(ipv6.Subnet)
+	data.IPv6Gateway = types.StringValue(ipv6.Gateway)
+}
@@ -0,0 +1,122 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkResource_Schema(t *testing.T) {
+	metadata := &resource.MetadataResponse{}
+	(&NetworkResource{}).Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "kind"}, metadata)
+	assert.Equal(t, "kind_network", metadata.TypeName)
+
+	resp := &resource.SchemaResponse{}
+	(&NetworkResource{}).Schema(t.Context(), resource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["name"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["ipv4_subnet"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["ipv4_gateway"].IsComputed())
+}
+
+func TestNetworkResource_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		values  map[string]tftypes.Value
+		name    string
+		wantErr bool
+	}{
+		{
+			name:   "name only",
+			values: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "team-a")},
+		},
+		{
+			name: "dual stack",
+			values: map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "team-a"),
+				"ipv4_subnet": tftypes.NewValue(tftypes.String, "172.30.0.0/16"),
+				"ipv6_subnet": tftypes.NewValue(tftypes.String, "fd00:30::/64"),
+				"mtu":         tftypes.NewValue(tftypes.Number, 1400),
+			},
+		},
+		{
+			name: "unknown subnet",
+			values: map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "team-a"),
+				"ipv4_subnet": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+		{
+			name: "invalid subnet",
+			values: map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "team-a"),
+				"ipv4_subnet": tftypes.NewValue(tftypes.String, "172.30.0.0"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid mtu",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "team-a"),
+				"mtu":  tftypes.NewValue(tftypes.Number, 100000),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			(&NetworkResource{}).ValidateConfig(
+				t.Context(),
+				resource.ValidateConfigRequest{Config: resourceTestConfig(t, &NetworkResource{}, tt.values)},
+				resp,
+			)
+
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func TestSetNetworkModel(t *testing.T) {
+	info, err := parseNetworkInspect(dockerNetworkInspect)
+	require.NoError(t, err)
+
+	data := NetworkResourceModel{Name: types.StringValue("kind"), MTU: types.Int64Null()}
+	setNetworkModel(&data, info)
+
+	assert.Equal(t, NetworkResourceModel{
+		ID:          types.StringValue("0f3c6d7e"),
+		Name:        types.StringValue("kind"),
+		IPv4Subnet:  types.StringValue("172.18.0.0/16"),
+		IPv4Gateway: types.StringValue("172.18.0.1"),
+		IPv6Subnet:  types.StringValue("fc00:f853:ccd:e793::/64"),
+		IPv6Gateway: types.StringValue("fc00:f853:ccd:e793::1"),
+		MTU:         types.Int64Null(),
+	}, data)
+
+	assert.Equal(t, &clusterNetwork{Name: "kind", IPv4Subnet: "172.18.0.0/16", IPv6Subnet: "fc00:f853:ccd:e793::/64"},
+		networkFromModel(&data))
+}
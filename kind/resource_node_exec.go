@@ -0,0 +1,393 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &NodeExecResource{}
+	_ resource.ResourceWithConfigure      = &NodeExecResource{}
+	_ resource.ResourceWithValidateConfig = &NodeExecResource{}
+
+	// nodeExecResultType is the element type of the results attribute.
+	//
+	//nolint:gochecknoglobals // constant attribute type
+	nodeExecResultType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"node":      types.StringType,
+		"stdout":    types.StringType,
+		"stderr":    types.StringType,
+		"exit_code": types.Int64Type,
+	}}
+)
+
+// NewNodeExecResource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewNodeExecResource() resource.Resource {
+	return &NodeExecResource{}
+}
+
+// NodeExecResource is the resource implementation.
+// NodeExecResourceModel describes the resource data model.
+type (
+	NodeExecResource struct {
+		// runtime holds the provider-level runtime settings used to reach the node containers
+		runtime runtimeSettings
+	}
+
+	NodeExecResourceModel struct {
+		runtimeSelection
+
+		ID          types.String `tfsdk:"id"`
+		ClusterName types.String `tfsdk:"cluster_name"`
+		Role        types.String `tfsdk:"role"`
+		NodeName    types.String `tfsdk:"node_name"`
+		Command     types.List   `tfsdk:"command"`
+		Triggers    types.Map    `tfsdk:"triggers"`
+		FailOnError types.Bool   `tfsdk:"fail_on_error"`
+		Results     types.List   `tfsdk:"results"`
+	}
+)
+
+// Configure adds the provider configured client to the resource.
+func (nodeExecResource *NodeExecResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	nodeExecResource.runtime = *settings
+}
+
+// Metadata returns the resource type name.
+func (*NodeExecResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_exec"
+}
+
+// Schema defines the schema for the resource.
+func (*NodeExecResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the node exec resource.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"cluster_name": schema.StringAttribute{
+			Required:    true,
+			Description: "Name of the Kind cluster, reached through runtime, docker_host and docker_context.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"role": schema.StringAttribute{
+			Optional:    true,
+			Description: "Run on the nodes with this role: 'control-plane', 'worker' or 'external-load-balancer'. Conflicts with node_name; all nodes when neither is set.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"node_name": schema.StringAttribute{
+			Optional:    true,
+			Description: "Run on the node container with this name (ex: my-cluster-worker2). Conflicts with role.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"command": schema.ListAttribute{
+			Required:    true,
+			ElementType: types.StringType,
+			Description: "Command and arguments, run without a shell (ex: [\"sh\", \"-c\", \"update-ca-certificates\"]).",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+		},
+		"triggers": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Arbitrary values that run the command again when they change.",
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
+		},
+		"fail_on_error": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(true),
+			Description: "Fail the apply when the command exits with a non-zero code on any node. Defaults to true.",
+		},
+		"results": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Output and exit code of the command on every selected node.",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"node":      schema.StringAttribute{Computed: true, Description: "Name of the node container."},
+					"stdout":    schema.StringAttribute{Computed: true, Description: "Standard output of the command."},
+					"stderr":    schema.StringAttribute{Computed: true, Description: "Standard error of the command."},
+					"exit_code": schema.Int64Attribute{Computed: true, Description: "Exit code of the command."},
+				},
+			},
+		},
+	}
+
+	maps.Copy(attributes, runtimeSelectionAttributes())
+
+	resp.Schema = schema.Schema{
+		Description: "Runs a command inside the node containers of a Kind cluster. The command runs again whenever an argument or triggers change.",
+		Attributes:  attributes,
+	}
+}
+
+// ValidateConfig checks the node selection and the command.
+func (*NodeExecResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data NodeExecResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values may still turn out null, so only known values conflict
+	if !data.Role.IsNull() && !data.Role.IsUnknown() && !data.NodeName.IsNull() && !data.NodeName.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("node_name"),
+			"Invalid node selection",
+			"Only one of role or node_name can be set.",
+		)
+	}
+
+	_, validRole := nodeStartOrder[data.Role.ValueString()]
+	if !data.Role.IsNull() && !data.Role.IsUnknown() && !validRole {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Invalid node selection",
+			fmt.Sprintf(
+				"The role must be one of %q, %q or %q, got %q.",
+				constants.ControlPlaneNodeRoleValue,
+				constants.WorkerNodeRoleValue,
+				constants.ExternalLoadBalancerNodeRoleValue,
+				data.Role.ValueString(),
+			),
+		)
+	}
+
+	if data.Command.IsNull() || data.Command.IsUnknown() {
+		return
+	}
+
+	if len(data.Command.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("command"), "Invalid command", "The command cannot be empty.")
+	}
+
+	// Null arguments would be dropped from the command, so they are rejected rather than ignored
+	for index, arg := range data.Command.Elements() {
+		if arg.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("command").AtListIndex(index),
+				"Invalid command",
+				"The command arguments cannot be null.",
+			)
+		}
+	}
+}
+
+// Create runs the command and records its results.
+func (nodeExecResource *NodeExecResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data NodeExecResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.ClusterName.ValueString()
+	command := listToStringSlice(data.Command)
+
+	runtime, err := data.kindRuntime(nodeExecResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	nodeList, err := runtime.selectClusterNodes(name, data.Role.ValueString(), data.NodeName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error selecting nodes", err.Error())
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Running %s on %d nodes of cluster %s", command[0], len(nodeList), name))
+
+	results, err := runtime.execInNodes(ctx, nodeList, command)
+	if err != nil {
+		resp.Diagnostics.AddError("Error running command", err.Error())
+
+		return
+	}
+
+	if data.FailOnError.ValueBool() {
+		for _, result := range results {
+			if result.exitCode != 0 {
+				resp.Diagnostics.AddError(
+					"Command failed",
+					fmt.Sprintf("%s exited with code %d on node %s: %s",
+						command[0], result.exitCode, result.node, strings.TrimSpace(result.stderr)),
+				)
+			}
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	selector := data.Role.ValueString() + "/" + data.NodeName.ValueString()
+	hash := sha256.Sum256([]byte(name + "\x00" + selector + "\x00" + strings.Join(command, "\x00")))
+	data.ID = types.StringValue(hex.EncodeToString(hash[:8]))
+
+	var listDiags diag.Diagnostics
+
+	data.Results, listDiags = nodeExecResultsValue(results)
+	resp.Diagnostics.Append(listDiags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the recorded results, the command is not run again on refresh.
+func (*NodeExecResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data NodeExecResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only applies fail_on_error, every other change runs the command again through replacement.
+func (*NodeExecResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data NodeExecResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from state, the effects of the command are not undone.
+func (*NodeExecResource) Delete(
+	_ context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+}
+
+// nodeExecResultsValue builds the results attribute.
+func nodeExecResultsValue(results []nodeExecResult) (types.List, diag.Diagnostics) {
+	elements := make([]attr.Value, 0, len(results))
+
+	var diags diag.Diagnostics
+
+	for _, result := range results {
+		element, objectDiags := types.ObjectValue(nodeExecResultType.AttrTypes, map[string]attr.Value{
+			"node":      types.StringValue(result.node),
+			"stdout":    types.StringValue(result.stdout),
+			"stderr":    types.StringValue(result.stderr),
+			"exit_code": types.Int64Value(int64(result.exitCode)),
+		})
+		diags.Append(objectDiags...)
+
+		elements = append(elements, element)
+	}
+
+	list, listDiags := types.ListValue(nodeExecResultType, elements)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
@@ -0,0 +1,137 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeExecResource_Schema(t *testing.T) {
+	metadata := &resource.MetadataResponse{}
+	(&NodeExecResource{}).Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "kind"}, metadata)
+	assert.Equal(t, "kind_node_exec", metadata.TypeName)
+
+	resp := &resource.SchemaResponse{}
+	(&NodeExecResource{}).Schema(t.Context(), resource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["command"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["results"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["runtime"].IsOptional())
+	assert.True(t, resp.Schema.Attributes["docker_host"].IsOptional())
+}
+
+func TestNodeExecResource_ValidateConfig(t *testing.T) {
+	command := func(args ...string) tftypes.Value {
+		values := make([]tftypes.Value, 0, len(args))
+		for _, arg := range args {
+			values = append(values, tftypes.NewValue(tftypes.String, arg))
+		}
+
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	tests := []struct {
+		values  map[string]tftypes.Value
+		name    string
+		wantErr bool
+	}{
+		{
+			name:   "role",
+			values: map[string]tftypes.Value{"role": tftypes.NewValue(tftypes.String, "worker"), "command": command("true")},
+		},
+		{
+			name: "role and node name",
+			values: map[string]tftypes.Value{
+				"role":      tftypes.NewValue(tftypes.String, "worker"),
+				"node_name": tftypes.NewValue(tftypes.String, "test-worker"),
+				"command":   command("true"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "role and unknown node name",
+			values: map[string]tftypes.Value{
+				"role":      tftypes.NewValue(tftypes.String, "worker"),
+				"node_name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"command":   command("true"),
+			},
+		},
+		{
+			name:   "unknown role",
+			values: map[string]tftypes.Value{"role": tftypes.NewValue(tftypes.String, tftypes.UnknownValue), "command": command("true")},
+		},
+		{
+			name:    "invalid role",
+			values:  map[string]tftypes.Value{"role": tftypes.NewValue(tftypes.String, "workers"), "command": command("true")},
+			wantErr: true,
+		},
+		{
+			name:    "empty command",
+			values:  map[string]tftypes.Value{"command": command()},
+			wantErr: true,
+		},
+		{
+			name: "null argument",
+			values: map[string]tftypes.Value{"command": tftypes.NewValue(
+				tftypes.List{ElementType: tftypes.String},
+				[]tftypes.Value{tftypes.NewValue(tftypes.String, nil)},
+			)},
+			wantErr: true,
+		},
+		{
+			name: "unknown argument",
+			values: map[string]tftypes.Value{"command": tftypes.NewValue(
+				tftypes.List{ElementType: tftypes.String},
+				[]tftypes.Value{tftypes.NewValue(tftypes.String, "echo"), tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+			)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.values["cluster_name"] = tftypes.NewValue(tftypes.String, "test")
+
+			resp := &resource.ValidateConfigResponse{}
+			req := resource.ValidateConfigRequest{Config: resourceTestConfig(t, &NodeExecResource{}, tt.values)}
+
+			(&NodeExecResource{}).ValidateConfig(t.Context(), req, resp)
+
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestNodeExecResultsValue(t *testing.T) {
+	results, diags := nodeExecResultsValue([]nodeExecResult{
+		{node: "test-worker", stdout: "ok\n", exitCode: 0},
+		{node: "test-worker2", stderr: "failed\n", exitCode: 1},
+	})
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, []any{
+		map[string]any{"node": "test-worker", "stdout": "ok\n", "stderr": "", "exit_code": 0},
+		map[string]any{"node": "test-worker2", "stdout": "", "stderr": "failed\n", "exit_code": 1},
+	}, listToSlice(results))
+}
@@ -0,0 +1,551 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+// defaultNodeFileMode is the permission mode of node files when none is set.
+const defaultNodeFileMode = "0644"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &NodeFileResource{}
+	_ resource.ResourceWithConfigure      = &NodeFileResource{}
+	_ resource.ResourceWithModifyPlan     = &NodeFileResource{}
+	_ resource.ResourceWithValidateConfig = &NodeFileResource{}
+)
+
+// ErrInvalidNodeFile is returned when a kind_node_file is misconfigured.
+//
+//nolint:grouper // false positive
+var ErrInvalidNodeFile = errors.New("invalid node file")
+
+// NewNodeFileResource is a helper function to simplify the provider implementation.
+//
+//nolint:ireturn // false positive
+func NewNodeFileResource() resource.Resource {
+	return &NodeFileResource{}
+}
+
+// NodeFileResource is the resource implementation.
+// NodeFileResourceModel describes the resource data model.
+type (
+	NodeFileResource struct {
+		// runtime holds the provider-level runtime settings used to reach the node containers
+		runtime runtimeSettings
+	}
+
+	NodeFileResourceModel struct {
+		runtimeSelection
+
+		ID          types.String `tfsdk:"id"`
+		ClusterName types.String `tfsdk:"cluster_name"`
+		Role        types.String `tfsdk:"role"`
+		NodeName    types.String `tfsdk:"node_name"`
+		Path        types.String `tfsdk:"path"`
+		Content     types.String `tfsdk:"content"`
+		Source      types.String `tfsdk:"source"`
+		Mode        types.String `tfsdk:"mode"`
+		RestartUnit types.String `tfsdk:"restart_unit"`
+		Checksum    types.String `tfsdk:"checksum"`
+	}
+)
+
+// Configure adds the provider configured client to the resource.
+func (nodeFileResource *NodeFileResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Provider data is nil until the provider itself has been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	settings, ok := req.ProviderData.(*runtimeSettings)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *runtimeSettings, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	nodeFileResource.runtime = *settings
+}
+
+// Metadata returns the resource type name.
+func (*NodeFileResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_file"
+}
+
+// Schema defines the schema for the resource.
+func (*NodeFileResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the node file resource.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"cluster_name": schema.StringAttribute{
+			Required:    true,
+			Description: "Name of the Kind cluster, reached through runtime, docker_host and docker_context.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"role": schema.StringAttribute{
+			Optional:    true,
+			Description: "Write to the nodes with this role: 'control-plane', 'worker' or 'external-load-balancer'. Conflicts with node_name; all nodes when neither is set.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"node_name": schema.StringAttribute{
+			Optional:    true,
+			Description: "Write to the node container with this name (ex: my-cluster-worker2). Conflicts with role.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"path": schema.StringAttribute{
+			Required:    true,
+			Description: "Absolute destination path inside the nodes (ex: /etc/kubernetes/audit-policy.yaml). Parent directories are created.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"content": schema.StringAttribute{
+			Optional:    true,
+			Description: "Content of the file. Conflicts with source.",
+		},
+		"source": schema.StringAttribute{
+			Optional:    true,
+			Description: "Local file to copy. Changes to its content are detected through checksum. Conflicts with content.",
+		},
+		"mode": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(defaultNodeFileMode),
+			Description: "Octal permission mode of the file. Defaults to " + defaultNodeFileMode + ".",
+		},
+		"restart_unit": schema.StringAttribute{
+			Optional:    true,
+			Description: "Systemd unit restarted inside the nodes when content or source changes (ex: containerd). Rewriting a file that drifted does not restart it.",
+		},
+		"checksum": schema.StringAttribute{
+			Computed:    true,
+			Description: "SHA-256 checksum of the content. Set to the checksum found in a node when the file drifted.",
+		},
+	}
+
+	maps.Copy(attributes, runtimeSelectionAttributes())
+
+	resp.Schema = schema.Schema{
+		Description: "Writes a file into the node containers of a Kind cluster. Files changed inside the nodes are written again.",
+		Attributes:  attributes,
+	}
+}
+
+// ValidateConfig checks the node selection, the path, the mode and the content source.
+func (*NodeFileResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data NodeFileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Role.IsNull() && !data.NodeName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("node_name"),
+			"Invalid node selection",
+			"Only one of role or node_name can be set.",
+		)
+	}
+
+	if !data.Content.IsUnknown() && !data.Source.IsUnknown() && data.Content.IsNull() == data.Source.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid node file",
+			"Exactly one of content or source must be set.",
+		)
+	}
+
+	if !data.Path.IsUnknown() && !filepath.IsAbs(data.Path.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Invalid node file",
+			fmt.Sprintf("%v: path %q must be absolute", ErrInvalidNodeFile, data.Path.ValueString()),
+		)
+	}
+
+	if !data.Mode.IsNull() && !data.Mode.IsUnknown() {
+		err := validateNodeFileMode(data.Mode.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("mode"), "Invalid node file", err.Error())
+		}
+	}
+}
+
+// ModifyPlan plans the checksum of the content, so that changes to a source file show up.
+// A source file that does not exist yet, e.g. one written by another resource during the
+// same apply, leaves the checksum unknown until it is read on apply.
+func (*NodeFileResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan NodeFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Content.IsUnknown() || plan.Source.IsUnknown() {
+		plan.Checksum = types.StringUnknown()
+	} else {
+		content, err := nodeFileContent(&plan)
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			plan.Checksum = types.StringUnknown()
+		case err != nil:
+			resp.Diagnostics.AddAttributeError(path.Root("source"), "Invalid node file", err.Error())
+
+			return
+		default:
+			plan.Checksum = types.StringValue(contentChecksum(content))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create writes the file into the selected nodes.
+func (nodeFileResource *NodeFileResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data NodeFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := nodeFileResource.write(ctx, &data, true)
+	if err != nil {
+		resp.Diagnostics.AddError("Error writing node file", err.Error())
+
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Path.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the checksum of the file in every selected node with the state.
+// The resource is removed from state when the nodes no longer exist.
+func (nodeFileResource *NodeFileResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data NodeFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := data.kindRuntime(nodeFileResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read node file", err.Error())
+
+		return
+	}
+
+	nodeList, err := runtime.selectClusterNodes(
+		data.ClusterName.ValueString(),
+		data.Role.ValueString(),
+		data.NodeName.ValueString(),
+	)
+	if errors.Is(err, ErrNoMatchingNodes) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read node file", err.Error())
+
+		return
+	}
+
+	checksums, err := runtime.nodeFileChecksums(ctx, nodeList, data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read node file", err.Error())
+
+		return
+	}
+
+	for node, checksum := range checksums {
+		if checksum != data.Checksum.ValueString() {
+			tflog.Info(ctx, fmt.Sprintf("File %s drifted on node %s", data.Path.ValueString(), node))
+
+			data.Checksum = types.StringValue(checksum)
+
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update writes the file again, restarting restart_unit when content or source changed.
+// Repairing drift alone rewrites the file without a restart.
+func (nodeFileResource *NodeFileResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data, state NodeFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := !data.Content.Equal(state.Content) || !data.Source.Equal(state.Source)
+
+	err := nodeFileResource.write(ctx, &data, changed)
+	if err != nil {
+		resp.Diagnostics.AddError("Error writing node file", err.Error())
+
+		return
+	}
+
+	data.ID = state.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the file from the selected nodes that still exist.
+func (nodeFileResource *NodeFileResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data NodeFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := data.kindRuntime(nodeFileResource.runtime)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider", err.Error())
+
+		return
+	}
+
+	nodeList, err := runtime.selectClusterNodes(
+		data.ClusterName.ValueString(),
+		data.Role.ValueString(),
+		data.NodeName.ValueString(),
+	)
+	if errors.Is(err, ErrNoMatchingNodes) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting node file", err.Error())
+
+		return
+	}
+
+	err = runtime.execInNodesChecked(ctx, nodeList, "rm", "-f", data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting node file", err.Error())
+	}
+}
+
+// write writes the file into the selected nodes, applies the mode, and restarts
+// restart_unit when restart is set.
+func (nodeFileResource *NodeFileResource) write(
+	ctx context.Context,
+	data *NodeFileResourceModel,
+	restart bool,
+) error {
+	content, err := nodeFileContent(data)
+	if err != nil {
+		return err
+	}
+
+	checksum := contentChecksum(content)
+
+	runtime, err := data.kindRuntime(nodeFileResource.runtime)
+	if err != nil {
+		return err
+	}
+
+	nodeList, err := runtime.selectClusterNodes(
+		data.ClusterName.ValueString(),
+		data.Role.ValueString(),
+		data.NodeName.ValueString(),
+	)
+	if err != nil {
+		return err
+	}
+
+	dest := data.Path.ValueString()
+
+	tflog.Info(ctx, fmt.Sprintf("Writing %s into %d nodes", dest, len(nodeList)))
+
+	err = runtime.writeNodeFiles(nodeList, map[string]string{dest: content})
+	if err != nil {
+		return err
+	}
+
+	err = runtime.execInNodesChecked(ctx, nodeList, "chmod", data.Mode.ValueString(), dest)
+	if err != nil {
+		return err
+	}
+
+	if unit := data.RestartUnit.ValueString(); restart && unit != "" {
+		tflog.Info(ctx, fmt.Sprintf("Restarting %s in %d nodes", unit, len(nodeList)))
+
+		err = runtime.execInNodesChecked(ctx, nodeList, "systemctl", "restart", unit)
+		if err != nil {
+			return err
+		}
+	}
+
+	data.Checksum = types.StringValue(checksum)
+
+	return nil
+}
+
+// nodeFileChecksums returns the SHA-256 checksum of a file in every node, empty when it is missing.
+func (r *kindRuntime) nodeFileChecksums(
+	ctx context.Context,
+	nodeList []nodes.Node,
+	filePath string,
+) (map[string]string, error) {
+	results, err := r.execInNodes(ctx, nodeList, []string{"sha256sum", filePath})
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string, len(results))
+
+	for _, result := range results {
+		checksum, _, _ := strings.Cut(result.stdout, " ")
+		if result.exitCode != 0 {
+			checksum = ""
+		}
+
+		checksums[result.node] = checksum
+	}
+
+	return checksums, nil
+}
+
+// nodeFileContent returns the content of the file, read from source when set.
+func nodeFileContent(data *NodeFileResourceModel) (string, error) {
+	if data.Source.IsNull() {
+		return data.Content.ValueString(), nil
+	}
+
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidNodeFile, err)
+	}
+
+	return string(content), nil
+}
+
+// validateNodeFileMode checks that mode is an octal permission mode.
+func validateNodeFileMode(mode string) error {
+	value, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || value > 0o7777 {
+		return fmt.Errorf("%w: mode %q must be an octal permission mode (ex: 0644)", ErrInvalidNodeFile, mode)
+	}
+
+	return nil
+}
+
+// contentChecksum returns the hex encoded SHA-256 checksum of content.
+func contentChecksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
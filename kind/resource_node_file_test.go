@@ -0,0 +1,248 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+)
+
+func TestNodeFileResource_Schema(t *testing.T) {
+	metadata := &resource.MetadataResponse{}
+	(&NodeFileResource{}).Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "kind"}, metadata)
+	assert.Equal(t, "kind_node_file", metadata.TypeName)
+
+	resp := &resource.SchemaResponse{}
+	(&NodeFileResource{}).Schema(t.Context(), resource.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.Schema.ValidateImplementation(t.Context()).HasError())
+
+	assert.True(t, resp.Schema.Attributes["path"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["checksum"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["runtime"].IsOptional())
+	assert.True(t, resp.Schema.Attributes["docker_context"].IsOptional())
+}
+
+func TestNodeFileResource_ValidateConfig(t *testing.T) {
+	str := func(value string) tftypes.Value { return tftypes.NewValue(tftypes.String, value) }
+
+	tests := []struct {
+		values  map[string]tftypes.Value
+		name    string
+		wantErr bool
+	}{
+		{
+			name:   "content",
+			values: map[string]tftypes.Value{"path": str("/etc/audit.yaml"), "content": str("a"), "mode": str("0600")},
+		},
+		{
+			name:   "source",
+			values: map[string]tftypes.Value{"path": str("/etc/audit.yaml"), "source": str("audit.yaml")},
+		},
+		{
+			name:    "content and source",
+			values:  map[string]tftypes.Value{"path": str("/etc/audit.yaml"), "content": str("a"), "source": str("audit.yaml")},
+			wantErr: true,
+		},
+		{
+			name:    "neither content nor source",
+			values:  map[string]tftypes.Value{"path": str("/etc/audit.yaml")},
+			wantErr: true,
+		},
+		{
+			name:    "relative path",
+			values:  map[string]tftypes.Value{"path": str("etc/audit.yaml"), "content": str("a")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			values:  map[string]tftypes.Value{"path": str("/etc/audit.yaml"), "content": str("a"), "mode": str("0999")},
+			wantErr: true,
+		},
+		{
+			name: "role and node name",
+			values: map[string]tftypes.Value{
+				"path":      str("/etc/audit.yaml"),
+				"content":   str("a"),
+				"role":      str("worker"),
+				"node_name": str("test-worker"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.values["cluster_name"] = str("test")
+
+			resp := &resource.ValidateConfigResponse{}
+			req := resource.ValidateConfigRequest{Config: resourceTestConfig(t, &NodeFileResource{}, tt.values)}
+
+			(&NodeFileResource{}).ValidateConfig(t.Context(), req, resp)
+
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestNodeFileResource_ModifyPlan(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "audit.yaml")
+	require.NoError(t, os.WriteFile(source, []byte("from file"), 0o600))
+
+	str := func(value string) tftypes.Value { return tftypes.NewValue(tftypes.String, value) }
+
+	tests := []struct {
+		name     string
+		source   string
+		checksum types.String
+	}{
+		{name: "existing source", source: source, checksum: types.StringValue(contentChecksum("from file"))},
+		{name: "source written during apply", source: source + ".pending", checksum: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := resourceTestConfig(t, &NodeFileResource{}, map[string]tftypes.Value{
+				"cluster_name": str("test"),
+				"path":         str("/etc/audit.yaml"),
+				"source":       str(tt.source),
+			})
+			plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			(&NodeFileResource{}).ModifyPlan(t.Context(), resource.ModifyPlanRequest{Plan: plan}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+
+			var checksum types.String
+			require.False(t, resp.Plan.GetAttribute(t.Context(), path.Root("checksum"), &checksum).HasError())
+			assert.Equal(t, tt.checksum, checksum)
+		})
+	}
+}
+
+func TestValidateNodeFileMode(t *testing.T) {
+	for _, mode := range []string{"0644", "600", "4755"} {
+		require.NoError(t, validateNodeFileMode(mode), mode)
+	}
+
+	for _, mode := range []string{"", "rw-r--r--", "0888", "17777"} {
+		require.ErrorIs(t, validateNodeFileMode(mode), ErrInvalidNodeFile, mode)
+	}
+}
+
+func TestNodeFileContent(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "audit.yaml")
+	require.NoError(t, os.WriteFile(source, []byte("from file"), 0o600))
+
+	content, err := nodeFileContent(&NodeFileResourceModel{
+		Content: types.StringValue("inline"),
+		Source:  types.StringNull(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "inline", content)
+
+	content, err = nodeFileContent(&NodeFileResourceModel{Content: types.StringNull(), Source: types.StringValue(source)})
+	require.NoError(t, err)
+	assert.Equal(t, "from file", content)
+
+	_, err = nodeFileContent(&NodeFileResourceModel{Source: types.StringValue(source + ".missing")})
+	require.ErrorIs(t, err, ErrInvalidNodeFile)
+
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", contentChecksum(""))
+}
+
+func TestNodeFileChecksums(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("policy"), 0o600))
+
+	runtime := &kindRuntime{name: providerDocker}
+	nodeList := []nodes.Node{localNode{testNode{name: "test-worker"}}}
+
+	checksums, err := runtime.nodeFileChecksums(t.Context(), nodeList, file)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"test-worker": contentChecksum("policy")}, checksums)
+
+	checksums, err = runtime.nodeFileChecksums(t.Context(), nodeList, file+".missing")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"test-worker": ""}, checksums, "missing files have no checksum")
+
+	require.NoError(t, runtime.execInNodesChecked(t.Context(), nodeList, "true"))
+	require.Error(t, runtime.execInNodesChecked(t.Context(), nodeList, "false"))
+}
+
+func TestNodeFileResource_UpdateRestart(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantRestart bool
+	}{
+		{name: "content changed", content: "new", wantRestart: true},
+		{name: "drift repaired", content: "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, calls := fakeRuntimeCLI(t, map[string]string{"ps -a": "test-worker\n", "inspect --format": "worker\n"})
+			t.Setenv("PATH", filepath.Dir(fake.name)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			nodeFileResource := &NodeFileResource{}
+			values := func(content, checksum string) tfsdk.State {
+				config := resourceTestConfig(t, nodeFileResource, map[string]tftypes.Value{
+					"id":           tftypes.NewValue(tftypes.String, "test:/etc/app.conf"),
+					"cluster_name": tftypes.NewValue(tftypes.String, "test"),
+					"runtime":      tftypes.NewValue(tftypes.String, providerDocker),
+					"path":         tftypes.NewValue(tftypes.String, "/etc/app.conf"),
+					"content":      tftypes.NewValue(tftypes.String, content),
+					"mode":         tftypes.NewValue(tftypes.String, "0644"),
+					"restart_unit": tftypes.NewValue(tftypes.String, "app"),
+					"checksum":     tftypes.NewValue(tftypes.String, checksum),
+				})
+
+				return tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+			}
+
+			// The prior state holds the checksum Read found on the drifted node
+			state := values("old", contentChecksum("drifted"))
+			plan := values(tt.content, contentChecksum(tt.content))
+
+			resp := &resource.UpdateResponse{State: state}
+			nodeFileResource.Update(t.Context(), resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State: state,
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			restarted := slices.ContainsFunc(calls(), func(call string) bool {
+				return strings.HasSuffix(call, "systemctl restart app")
+			})
+			assert.Equal(t, tt.wantRestart, restarted)
+		})
+	}
+}
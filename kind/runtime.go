@@ -0,0 +1,388 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sigs.k8s.io/kind/pkg/cluster"
+)
+
+// ErrRuntimeContextUnsupported is returned when a runtime context is requested for nerdctl.
+//
+//nolint:grouper // false positive
+var ErrRuntimeContextUnsupported = errors.New("docker_context is not supported by nerdctl")
+
+// runtimeSettings holds the container runtime connection settings shared by
+// the provider configuration and the individual resources.
+type runtimeSettings struct {
+	DockerHost    string
+	DockerContext string
+}
+
+// runtimeSelection holds the attributes of resources working on an existing cluster that select
+// the container runtime of that cluster, matching the runtime settings of kind_cluster.
+type runtimeSelection struct {
+	Runtime       types.String `tfsdk:"runtime"`
+	DockerHost    types.String `tfsdk:"docker_host"`
+	DockerContext types.String `tfsdk:"docker_context"`
+}
+
+// runtimeSelectionAttributes returns the schema of the runtimeSelection attributes.
+// They point the resource at another cluster when changed, so they force replacement.
+func runtimeSelectionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"runtime": schema.StringAttribute{
+			Optional:    true,
+			Description: "Container runtime provider of the cluster: 'docker', 'podman', or 'nerdctl'. Auto-detected if not set.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"docker_host": schema.StringAttribute{
+			Optional:    true,
+			Description: "Container runtime host of the cluster (ex: ssh://user@build-box). Overrides the provider-level docker_host.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"docker_context": schema.StringAttribute{
+			Optional:    true,
+			Description: "Docker context (or podman connection) of the cluster. Overrides the provider-level docker_context.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+// kindRuntime creates the kindRuntime the attributes select, falling back to the provider-level settings.
+func (s runtimeSelection) kindRuntime(fallback runtimeSettings) (*kindRuntime, error) {
+	settings := runtimeSettings{
+		DockerHost:    s.DockerHost.ValueString(),
+		DockerContext: s.DockerContext.ValueString(),
+	}
+
+	return newKindRuntime(s.Runtime.ValueString(), settings.merge(fallback))
+}
+
+// merge returns the settings with empty fields filled in from the fallback settings.
+func (s runtimeSettings) merge(fallback runtimeSettings) runtimeSettings {
+	if s.DockerHost == "" && s.DockerContext == "" {
+		return fallback
+	}
+
+	return s
+}
+
+// env returns the environment variables that point the runtime CLI at the configured host or context.
+func (s runtimeSettings) env(runtime string) (map[string]string, error) {
+	env := make(map[string]string)
+
+	switch runtime {
+	case providerPodman:
+		if s.DockerHost != "" {
+			env["CONTAINER_HOST"] = s.DockerHost
+		}
+
+		if s.DockerContext != "" {
+			env["CONTAINER_CONNECTION"] = s.DockerContext
+		}
+	case providerNerdctl:
+		if s.DockerContext != "" {
+			return nil, ErrRuntimeContextUnsupported
+		}
+
+		if s.DockerHost != "" {
+			env["CONTAINERD_ADDRESS"] = strings.TrimPrefix(s.DockerHost, "unix://")
+		}
+	default:
+		if s.DockerHost != "" {
+			env["DOCKER_HOST"] = s.DockerHost
+		}
+
+		if s.DockerContext != "" {
+			env["DOCKER_CONTEXT"] = s.DockerContext
+		}
+	}
+
+	return env, nil
+}
+
+// remoteHost resolves the address of the machine running the container runtime.
+// It returns an empty string when the runtime is local.
+func (s runtimeSettings) remoteHost(ctx context.Context, runtime string) (string, error) {
+	endpoint := s.DockerHost
+	if endpoint == "" && s.DockerContext != "" {
+		resolved, err := runtimeContextEndpoint(ctx, runtime, s.DockerContext)
+		if err != nil {
+			return "", err
+		}
+
+		endpoint = resolved
+	}
+
+	return remoteHostFromEndpoint(endpoint), nil
+}
+
+// remoteHostFromEndpoint extracts the host name from a runtime endpoint such as
+// ssh://user@host or tcp://host:2376. Local sockets and loopback hosts yield an empty string.
+func remoteHostFromEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+
+	switch parsed.Scheme {
+	case "ssh", "tcp", "http", "https":
+	default:
+		return ""
+	}
+
+	host := parsed.Hostname()
+	if host == "localhost" {
+		return ""
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return ""
+	}
+
+	return host
+}
+
+// runtimeContextEndpoint resolves the endpoint of a named docker context or podman connection.
+func runtimeContextEndpoint(ctx context.Context, runtime, contextName string) (string, error) {
+	switch runtime {
+	case providerPodman:
+		output, err := exec.CommandContext(
+			ctx, providerPodman, "system", "connection", "list", "--format", "json",
+		).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to list podman connections: %w", err)
+		}
+
+		var connections []struct {
+			Name string `json:"Name"` //nolint:tagliatelle // podman output format
+			URI  string `json:"URI"`  //nolint:tagliatelle // podman output format
+		}
+
+		err = json.Unmarshal(output, &connections)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse podman connections: %w", err)
+		}
+
+		for _, connection := range connections {
+			if connection.Name == contextName {
+				return connection.URI, nil
+			}
+		}
+
+		return "", fmt.Errorf("podman connection %q not found", contextName)
+	case providerNerdctl:
+		return "", ErrRuntimeContextUnsupported
+	default:
+		output, err := exec.CommandContext(
+			ctx, providerDocker, "context", "inspect", contextName,
+			"--format", "{{.Endpoints.docker.Host}}",
+		).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to inspect docker context %q: %w", contextName, err)
+		}
+
+		return strings.TrimSpace(string(output)), nil
+	}
+}
+
+// runtimeEnvGuard coordinates process environment overrides for container runtime calls.
+// kind shells out to the runtime CLI with the plugin process environment, so calls that need
+// different environments are serialized while calls sharing the same environment run concurrently.
+//
+//nolint:gochecknoglobals // the process environment is global state
+var runtimeEnvGuard = newRuntimeEnvLock()
+
+// runtimeEnvLock is a lock that admits concurrent holders only when they share the same environment.
+type runtimeEnvLock struct {
+	cond   *sync.Cond
+	saved  map[string]savedEnvVar
+	key    string
+	active int
+}
+
+// savedEnvVar records the original value of an overridden environment variable.
+type savedEnvVar struct {
+	value   string
+	present bool
+}
+
+// newRuntimeEnvLock creates an unlocked runtimeEnvLock.
+func newRuntimeEnvLock() *runtimeEnvLock {
+	return &runtimeEnvLock{cond: sync.NewCond(&sync.Mutex{})}
+}
+
+// withRuntimeEnv runs fn with the environment overrides applied to the process and restores
+// the original environment once no other call is using the same overrides.
+func withRuntimeEnv(env map[string]string, fn func() error) error {
+	runtimeEnvGuard.acquire(env)
+	defer runtimeEnvGuard.release()
+
+	return fn()
+}
+
+// acquire waits until the environment is free or already set to env, then applies it.
+func (l *runtimeEnvLock) acquire(env map[string]string) {
+	key := runtimeEnvKey(env)
+
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	for l.active > 0 && l.key != key {
+		l.cond.Wait()
+	}
+
+	if l.active == 0 {
+		l.key = key
+		l.saved = make(map[string]savedEnvVar, len(env))
+
+		for name, value := range env {
+			original, present := os.LookupEnv(name)
+			l.saved[name] = savedEnvVar{value: original, present: present}
+
+			_ = os.Setenv(name, value)
+		}
+	}
+
+	l.active++
+}
+
+// release drops one holder and restores the environment when the last holder leaves.
+func (l *runtimeEnvLock) release() {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	l.active--
+	if l.active > 0 {
+		return
+	}
+
+	for name, original := range l.saved {
+		if original.present {
+			_ = os.Setenv(name, original.value)
+		} else {
+			_ = os.Unsetenv(name)
+		}
+	}
+
+	l.saved = nil
+	l.key = ""
+	l.cond.Broadcast()
+}
+
+// runtimeEnvKey returns a stable identity for a set of environment overrides.
+func runtimeEnvKey(env map[string]string) string {
+	pairs := make([]string, 0, len(env))
+	for name, value := range env {
+		pairs = append(pairs, name+"="+value)
+	}
+
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, "\x00")
+}
+
+// kindRuntime bundles a container runtime with the settings its kind calls must run with.
+type kindRuntime struct {
+	env      map[string]string
+	settings runtimeSettings
+	name     string
+}
+
+// newKindRuntime creates a kindRuntime for the named runtime and connection settings.
+// An empty name resolves the runtime kind would detect, so the connection settings are
+// rendered for that runtime rather than for docker.
+func newKindRuntime(name string, settings runtimeSettings) (*kindRuntime, error) {
+	if name == "" {
+		// Left empty when nothing is installed, kind reports the missing runtime itself
+		name, _ = runtimeBinary("")
+	}
+
+	env, err := settings.env(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject unsupported runtimes up front, the provider itself is created by run
+	if name != "" {
+		_, err = newKindProvider(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &kindRuntime{
+		env:      env,
+		settings: settings,
+		name:     name,
+	}, nil
+}
+
+// withEnv returns a copy of the runtime that also applies the extra environment variables.
+// Calls through the copy only share the process environment with calls using the same overrides.
+func (r *kindRuntime) withEnv(extra map[string]string) *kindRuntime {
+	env := make(map[string]string, len(r.env)+len(extra))
+	maps.Copy(env, r.env)
+	maps.Copy(env, extra)
+
+	return &kindRuntime{
+		env:      env,
+		settings: r.settings,
+		name:     r.name,
+	}
+}
+
+// run calls fn with a kind provider while the runtime environment is applied.
+// The provider is created under the environment too, as kind's runtime detection
+// probes the runtime with it.
+func (r *kindRuntime) run(fn func(provider *cluster.Provider) error) error {
+	return withRuntimeEnv(r.env, func() error {
+		provider, err := newKindProvider(r.name)
+		if err != nil {
+			return err
+		}
+
+		return fn(provider)
+	})
+}
@@ -0,0 +1,212 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEnvVar is an environment variable name reserved for runtime env tests.
+const testEnvVar = "TF_KIND_RUNTIME_TEST_VAR"
+
+func TestRuntimeSettingsMerge(t *testing.T) {
+	fallback := runtimeSettings{DockerHost: "ssh://fallback"}
+
+	assert.Equal(t, fallback, runtimeSettings{}.merge(fallback), "empty settings use the fallback")
+
+	own := runtimeSettings{DockerContext: "remote"}
+	assert.Equal(t, own, own.merge(fallback), "resource settings take precedence as a whole")
+}
+
+func TestRuntimeSettingsEnv(t *testing.T) {
+	tests := []struct {
+		settings runtimeSettings
+		expected map[string]string
+		name     string
+		runtime  string
+		wantErr  bool
+	}{
+		{
+			name:     "docker host and context",
+			runtime:  providerDocker,
+			settings: runtimeSettings{DockerHost: "ssh://box", DockerContext: "remote"},
+			expected: map[string]string{"DOCKER_HOST": "ssh://box", "DOCKER_CONTEXT": "remote"},
+		},
+		{
+			name:     "auto-detect uses docker variables",
+			runtime:  "",
+			settings: runtimeSettings{DockerHost: "tcp://box:2376"},
+			expected: map[string]string{"DOCKER_HOST": "tcp://box:2376"},
+		},
+		{
+			name:     "podman host and connection",
+			runtime:  providerPodman,
+			settings: runtimeSettings{DockerHost: "ssh://box", DockerContext: "remote"},
+			expected: map[string]string{"CONTAINER_HOST": "ssh://box", "CONTAINER_CONNECTION": "remote"},
+		},
+		{
+			name:     "nerdctl address",
+			runtime:  providerNerdctl,
+			settings: runtimeSettings{DockerHost: "unix:///run/containerd/containerd.sock"},
+			expected: map[string]string{"CONTAINERD_ADDRESS": "/run/containerd/containerd.sock"},
+		},
+		{
+			name:     "nerdctl rejects contexts",
+			runtime:  providerNerdctl,
+			settings: runtimeSettings{DockerContext: "remote"},
+			wantErr:  true,
+		},
+		{
+			name:     "no settings yields empty env",
+			runtime:  providerDocker,
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := tt.settings.env(tt.runtime)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrRuntimeContextUnsupported)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, env)
+		})
+	}
+}
+
+func TestNewKindRuntime_DetectedRuntime(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, providerPodman), []byte("#!/bin/sh\n"), 0o755)) //nolint:gosec // test executable
+	t.Setenv("PATH", dir)
+
+	runtime, err := newKindRuntime("", runtimeSettings{DockerHost: "unix:///run/podman/podman.sock"})
+	require.NoError(t, err)
+
+	assert.Equal(t, providerPodman, runtime.name)
+	assert.Equal(t, map[string]string{"CONTAINER_HOST": "unix:///run/podman/podman.sock"}, runtime.env)
+
+	_, err = newKindRuntime("containerd", runtimeSettings{})
+	require.Error(t, err)
+}
+
+func TestRuntimeSelectionKindRuntime(t *testing.T) {
+	fallback := runtimeSettings{DockerHost: "ssh://fallback"}
+
+	runtime, err := runtimeSelection{
+		Runtime:       types.StringValue(providerPodman),
+		DockerHost:    types.StringValue("unix:///run/podman/podman.sock"),
+		DockerContext: types.StringNull(),
+	}.kindRuntime(fallback)
+	require.NoError(t, err)
+
+	assert.Equal(t, providerPodman, runtime.name)
+	assert.Equal(t, map[string]string{"CONTAINER_HOST": "unix:///run/podman/podman.sock"}, runtime.env)
+
+	runtime, err = runtimeSelection{Runtime: types.StringValue(providerDocker)}.kindRuntime(fallback)
+	require.NoError(t, err)
+
+	assert.Equal(t, fallback, runtime.settings, "unset attributes use the provider-level settings")
+}
+
+func TestRemoteHostFromEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		expected string
+	}{
+		{name: "empty", endpoint: "", expected: ""},
+		{name: "ssh with user", endpoint: "ssh://builder@build-box", expected: "build-box"},
+		{name: "tcp with port", endpoint: "tcp://10.0.0.5:2376", expected: "10.0.0.5"},
+		{name: "unix socket", endpoint: "unix:///var/run/docker.sock", expected: ""},
+		{name: "loopback", endpoint: "tcp://127.0.0.1:2375", expected: ""},
+		{name: "localhost", endpoint: "ssh://localhost", expected: ""},
+		{name: "docker-in-docker", endpoint: "tcp://docker:2376", expected: "docker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, remoteHostFromEndpoint(tt.endpoint))
+		})
+	}
+}
+
+func TestWithRuntimeEnv(t *testing.T) {
+	t.Setenv(testEnvVar, "original")
+
+	err := withRuntimeEnv(map[string]string{testEnvVar: "override"}, func() error {
+		assert.Equal(t, "override", os.Getenv(testEnvVar))
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "original", os.Getenv(testEnvVar), "environment should be restored")
+
+	require.NoError(t, os.Unsetenv(testEnvVar))
+
+	err = withRuntimeEnv(map[string]string{testEnvVar: "override"}, func() error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, present := os.LookupEnv(testEnvVar)
+	assert.False(t, present, "previously unset variables should be unset again")
+}
+
+func TestWithRuntimeEnv_Concurrent(t *testing.T) {
+	t.Setenv(testEnvVar, "original")
+
+	var wg sync.WaitGroup
+
+	for _, value := range []string{"a", "b", "a", "b", "a"} {
+		wg.Go(func() {
+			_ = withRuntimeEnv(map[string]string{testEnvVar: value}, func() error {
+				assert.Equal(t, value, os.Getenv(testEnvVar), "environment must not change while held")
+
+				return nil
+			})
+		})
+	}
+
+	wg.Wait()
+	assert.Equal(t, "original", os.Getenv(testEnvVar))
+}
+
+func TestRuntimeEnvKey(t *testing.T) {
+	assert.Empty(t, runtimeEnvKey(nil))
+	assert.Equal(
+		t,
+		runtimeEnvKey(map[string]string{"A": "1", "B": "2"}),
+		runtimeEnvKey(map[string]string{"B": "2", "A": "1"}),
+		"key should not depend on map order",
+	)
+	assert.NotEqual(
+		t,
+		runtimeEnvKey(map[string]string{"A": "1"}),
+		runtimeEnvKey(map[string]string{"A": "2"}),
+	)
+}
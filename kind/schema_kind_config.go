@@ -17,13 +17,19 @@
 package kind
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // kindConfigBlocks returns the blocks for the resource schema.
@@ -32,13 +38,17 @@ func kindConfigBlocks() map[string]schema.Block {
 		"kind_config": schema.ListNestedBlock{
 			Description: "The kind_config that kind will use to bootstrap the cluster.",
 			PlanModifiers: []planmodifier.List{
-				listplanmodifier.RequiresReplace(),
+				requiresReplaceIfCountChanges(),
 			},
 			NestedObject: schema.NestedBlockObject{
 				Attributes: kindConfigFieldsFramework(),
 				Blocks:     kindConfigNestedBlocks(),
 			},
 		},
+		"timeouts": timeoutsBlock(),
+		"wait_for": waitForBlock(),
+		"proxy":    proxyBlock(),
+		"network":  networkBlock(),
 	}
 }
 
@@ -64,18 +74,29 @@ func kindConfigFieldsFramework() map[string]schema.Attribute {
 		},
 		"containerd_config_patches": schema.ListAttribute{
 			Optional:    true,
-			ElementType: types.StringType,
-			Description: "Containerd configuration patches in TOML format.",
+			Computed:    true,
+			ElementType: TOMLPatchType{},
+			Description: "Containerd configuration patches in TOML format. Formatting-only changes are ignored.",
+			PlanModifiers: []planmodifier.List{
+				useStateForSemanticallyEqualPatches(),
+				listplanmodifier.RequiresReplace(),
+			},
 		},
 		"runtime_config": schema.MapAttribute{
 			Optional:    true,
 			ElementType: types.StringType,
 			Description: "Runtime configuration options (underscores in keys are converted to slashes).",
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
 		},
 		"feature_gates": schema.MapAttribute{
 			Optional:    true,
 			ElementType: types.StringType,
 			Description: "Feature gates to enable/disable.",
+			PlanModifiers: []planmodifier.Map{
+				mapplanmodifier.RequiresReplace(),
+			},
 		},
 	}
 }
@@ -90,20 +111,51 @@ func kindConfigNestedBlocks() map[string]schema.Block {
 					"role": schema.StringAttribute{
 						Optional:    true,
 						Description: "Node role: 'control-plane' or 'worker'.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"image": schema.StringAttribute{
 						Optional:    true,
 						Description: "Node image to use (overrides cluster-level node_image).",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"labels": schema.MapAttribute{
 						Optional:    true,
 						ElementType: types.StringType,
-						Description: "Labels to apply to the node.",
+						Description: "Labels to apply to the node. Updated in place through the Kubernetes API.",
+					},
+					"taints": schema.ListNestedAttribute{
+						Optional:    true,
+						Description: "Taints to apply to the node. Updated in place through the Kubernetes API.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"key": schema.StringAttribute{
+									Required:    true,
+									Description: "Taint key.",
+								},
+								"value": schema.StringAttribute{
+									Optional:    true,
+									Description: "Taint value.",
+								},
+								"effect": schema.StringAttribute{
+									Required:    true,
+									Description: "Taint effect: 'NoSchedule', 'PreferNoSchedule', or 'NoExecute'.",
+								},
+							},
+						},
 					},
 					"kubeadm_config_patches": schema.ListAttribute{
 						Optional:    true,
-						ElementType: types.StringType,
-						Description: "Kubeadm config patches for this node.",
+						Computed:    true,
+						ElementType: YAMLPatchType{},
+						Description: "Kubeadm config patches for this node in YAML format. Formatting-only changes are ignored.",
+						PlanModifiers: []planmodifier.List{
+							useStateForSemanticallyEqualPatches(),
+							listplanmodifier.RequiresReplace(),
+						},
 					},
 					"extra_mounts": schema.ListNestedAttribute{
 						Optional:    true,
@@ -113,25 +165,43 @@ func kindConfigNestedBlocks() map[string]schema.Block {
 								"container_path": schema.StringAttribute{
 									Optional:    true,
 									Description: "Path in the container.",
+									PlanModifiers: []planmodifier.String{
+										stringplanmodifier.RequiresReplace(),
+									},
 								},
 								"host_path": schema.StringAttribute{
 									Optional:    true,
 									Description: "Path on the host.",
+									PlanModifiers: []planmodifier.String{
+										stringplanmodifier.RequiresReplace(),
+									},
 								},
 								"read_only": schema.BoolAttribute{
 									Optional:    true,
 									Description: "Mount as read-only.",
+									PlanModifiers: []planmodifier.Bool{
+										boolplanmodifier.RequiresReplace(),
+									},
 								},
 								"selinux_relabel": schema.BoolAttribute{
 									Optional:    true,
 									Description: "Enable SELinux relabeling.",
+									PlanModifiers: []planmodifier.Bool{
+										boolplanmodifier.RequiresReplace(),
+									},
 								},
 								"propagation": schema.StringAttribute{
 									Optional:    true,
 									Description: "Mount propagation: 'None', 'HostToContainer', or 'Bidirectional'.",
+									PlanModifiers: []planmodifier.String{
+										stringplanmodifier.RequiresReplace(),
+									},
 								},
 							},
 						},
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.RequiresReplace(),
+						},
 					},
 					"extra_port_mappings": schema.ListNestedAttribute{
 						Optional:    true,
@@ -147,7 +217,7 @@ func kindConfigNestedBlocks() map[string]schema.Block {
 								},
 								"host_port": schema.Int64Attribute{
 									Optional:    true,
-									Description: "Port on the host.",
+									Description: "Port on the host. 0 or unset lets the runtime pick a free port, reported in published_ports.",
 									PlanModifiers: []planmodifier.Int64{
 										int64planmodifier.RequiresReplace(),
 									},
@@ -155,15 +225,126 @@ func kindConfigNestedBlocks() map[string]schema.Block {
 								"listen_address": schema.StringAttribute{
 									Optional:    true,
 									Description: "Listen address on the host.",
+									PlanModifiers: []planmodifier.String{
+										stringplanmodifier.RequiresReplace(),
+									},
 								},
 								"protocol": schema.StringAttribute{
 									Optional:    true,
 									Description: "Protocol: 'TCP', 'UDP', or 'SCTP'.",
+									PlanModifiers: []planmodifier.String{
+										stringplanmodifier.RequiresReplace(),
+									},
 								},
 							},
 						},
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.RequiresReplace(),
+						},
 					},
 				},
+				Blocks: map[string]schema.Block{
+					"kubelet_config": kubeletConfigBlock(
+						"Kubelet configuration overrides for this node, passed as kubelet flags.",
+					),
+					"kubelet": schema.SingleNestedBlock{
+						Description: "Kubelet settings for this node.",
+						Attributes: map[string]schema.Attribute{
+							"extra_args": extraArgsAttribute("kubelet"),
+						},
+						PlanModifiers: []planmodifier.Object{
+							objectplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			PlanModifiers: []planmodifier.List{
+				requiresReplaceIfCountChanges(),
+			},
+		},
+		"kubelet_config": kubeletConfigBlock(
+			"Kubelet configuration for all nodes, rendered into a KubeletConfiguration patch.",
+		),
+		"containerd": schema.SingleNestedBlock{
+			Description: "Structured containerd settings for all nodes.",
+			Blocks: map[string]schema.Block{
+				"registry_mirror": schema.ListNestedBlock{
+					Description: "Registry mirrors, written as hosts.toml files under " + containerdCertsDir + ".",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"host": schema.StringAttribute{
+								Required:    true,
+								Description: "Registry host being mirrored (ex: docker.io or localhost:5000).",
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.RequiresReplace(),
+								},
+							},
+							"endpoints": schema.ListAttribute{
+								Required:    true,
+								ElementType: types.StringType,
+								Description: "Mirror endpoint URLs, tried in order (ex: http://kind-registry:5000).",
+								PlanModifiers: []planmodifier.List{
+									listplanmodifier.RequiresReplace(),
+								},
+							},
+							"skip_verify": schema.BoolAttribute{
+								Optional:    true,
+								Description: "Skip TLS verification of the mirror endpoints.",
+								PlanModifiers: []planmodifier.Bool{
+									boolplanmodifier.RequiresReplace(),
+								},
+							},
+							"ca_file": schema.StringAttribute{
+								Optional:    true,
+								Description: "Path on the host to a CA certificate trusted for the mirror endpoints.",
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.RequiresReplace(),
+								},
+							},
+						},
+					},
+					PlanModifiers: []planmodifier.List{
+						listplanmodifier.RequiresReplace(),
+					},
+				},
+			},
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
+			},
+		},
+		"api_server": schema.SingleNestedBlock{
+			Description: "API server settings rendered into the kubeadm ClusterConfiguration.",
+			Attributes: map[string]schema.Attribute{
+				"extra_args": extraArgsAttribute("kube-apiserver"),
+				"cert_sans": schema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "Additional Subject Alternative Names for the API server certificate.",
+					PlanModifiers: []planmodifier.List{
+						listplanmodifier.RequiresReplace(),
+					},
+				},
+			},
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
+			},
+		},
+		"controller_manager": schema.SingleNestedBlock{
+			Description: "Controller manager settings rendered into the kubeadm ClusterConfiguration.",
+			Attributes: map[string]schema.Attribute{
+				"extra_args": extraArgsAttribute("kube-controller-manager"),
+			},
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
+			},
+		},
+		"scheduler": schema.SingleNestedBlock{
+			Description: "Scheduler settings rendered into the kubeadm ClusterConfiguration.",
+			Attributes: map[string]schema.Attribute{
+				"extra_args": extraArgsAttribute("kube-scheduler"),
+			},
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
 			},
 		},
 		"networking": schema.SingleNestedBlock{
@@ -172,37 +353,225 @@ func kindConfigNestedBlocks() map[string]schema.Block {
 				"api_server_address": schema.StringAttribute{
 					Optional:    true,
 					Description: "API server listen address.",
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
 				},
 				"api_server_port": schema.Int64Attribute{
 					Optional:    true,
 					Description: "API server port.",
+					PlanModifiers: []planmodifier.Int64{
+						int64planmodifier.RequiresReplace(),
+					},
 				},
 				"pod_subnet": schema.StringAttribute{
 					Optional:    true,
 					Description: "Pod subnet CIDR.",
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
 				},
 				"service_subnet": schema.StringAttribute{
 					Optional:    true,
 					Description: "Service subnet CIDR.",
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
 				},
 				"disable_default_cni": schema.BoolAttribute{
 					Optional:    true,
 					Description: "Disable the default CNI.",
+					PlanModifiers: []planmodifier.Bool{
+						boolplanmodifier.RequiresReplace(),
+					},
 				},
 				"kube_proxy_mode": schema.StringAttribute{
 					Optional:    true,
 					Description: "Kube-proxy mode: 'iptables', 'ipvs', or 'none'.",
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
 				},
 				"ip_family": schema.StringAttribute{
 					Optional:    true,
 					Description: "IP family: 'ipv4', 'ipv6', or 'dual'.",
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
 				},
 				"dns_search": schema.ListAttribute{
 					Optional:    true,
 					ElementType: types.StringType,
 					Description: "DNS search domains.",
+					PlanModifiers: []planmodifier.List{
+						listplanmodifier.RequiresReplace(),
+					},
 				},
 			},
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+// extraArgsAttribute returns the schema for the extra command line arguments of a component.
+func extraArgsAttribute(component string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Extra " + component + " arguments without the leading dashes (ex: enable-admission-plugins).",
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplace(),
 		},
 	}
 }
+
+// kubeletConfigBlock returns the schema for a structured KubeletConfiguration block.
+func kubeletConfigBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"max_pods": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of pods per node.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"eviction_hard": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Hard eviction thresholds by signal (ex: memory.available = \"100Mi\", nodefs.available = \"10%\").",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"system_reserved": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Resources reserved for system daemons (cpu, memory, ephemeral-storage, pid).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"kube_reserved": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Resources reserved for Kubernetes daemons (cpu, memory, ephemeral-storage, pid).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"image_gc_high_threshold_percent": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Disk usage percentage after which image garbage collection always runs.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"image_gc_low_threshold_percent": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Disk usage percentage before which image garbage collection never runs.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"allowed_unsafe_sysctls": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Unsafe sysctls or sysctl patterns (ex: net.core.*) pods may set.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// blockCountChangeDescription describes the replacement triggered by requiresReplaceIfCountChanges.
+const blockCountChangeDescription = "If blocks are added or removed, Terraform will destroy and recreate the resource."
+
+// requiresReplaceIfCountChanges requires replacement when blocks are added or removed.
+// Changes inside existing blocks are classified by the plan modifiers of their attributes.
+//
+//nolint:ireturn // plan modifiers are returned as interfaces
+func requiresReplaceIfCountChanges() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = len(req.StateValue.Elements()) != len(req.PlanValue.Elements())
+		},
+		blockCountChangeDescription,
+		blockCountChangeDescription,
+	)
+}
+
+// semanticPatchesDescription describes useStateForSemanticallyEqualPatches.
+const semanticPatchesDescription = "Keeps the prior patches in the plan when the configured ones only differ in formatting."
+
+// semanticPatchesModifier implements useStateForSemanticallyEqualPatches.
+type semanticPatchesModifier struct{}
+
+// useStateForSemanticallyEqualPatches plans the prior value of a patch list whose configured
+// patches only differ in formatting from the prior ones, so re-indenting a heredoc does not
+// trip the replacement check that follows it. The framework only applies semantic equality
+// after apply and refresh, never while planning. The patch lists are Optional and Computed,
+// since a planned value that differs from the configuration is only allowed for computed attributes.
+//
+//nolint:ireturn // plan modifiers are returned as interfaces
+func useStateForSemanticallyEqualPatches() planmodifier.List {
+	return semanticPatchesModifier{}
+}
+
+// Description returns a plain text description of the modifier.
+func (semanticPatchesModifier) Description(_ context.Context) string {
+	return semanticPatchesDescription
+}
+
+// MarkdownDescription returns a markdown description of the modifier.
+func (semanticPatchesModifier) MarkdownDescription(_ context.Context) string {
+	return semanticPatchesDescription
+}
+
+// PlanModifyList plans null for unset patches, and the prior patches when every configured
+// patch is semantically equal to the prior patch at the same index.
+func (semanticPatchesModifier) PlanModifyList(
+	ctx context.Context,
+	req planmodifier.ListRequest,
+	resp *planmodifier.ListResponse,
+) {
+	// Computed only to allow the prior value, an unset list stays unset
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = types.ListNull(req.ConfigValue.ElementType(ctx))
+
+		return
+	}
+
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() ||
+		len(req.StateValue.Elements()) != len(req.PlanValue.Elements()) {
+		return
+	}
+
+	prior := req.StateValue.Elements()
+
+	for i, planned := range req.PlanValue.Elements() {
+		priorPatch, priorOK := prior[i].(basetypes.StringValuableWithSemanticEquals)
+		plannedPatch, plannedOK := planned.(basetypes.StringValuable)
+
+		if !priorOK || !plannedOK || planned.IsUnknown() || planned.IsNull() || prior[i].IsNull() {
+			return
+		}
+
+		equal, diags := priorPatch.StringSemanticEquals(ctx, plannedPatch)
+		resp.Diagnostics.Append(diags...)
+
+		if !equal {
+			return
+		}
+	}
+
+	resp.PlanValue = req.StateValue
+}
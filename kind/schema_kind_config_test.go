@@ -119,6 +119,21 @@ func TestKindConfigNestedBlocks(t *testing.T) {
 			expectedKey: networkingBlockName,
 			description: "blocks should have networking key",
 		},
+		{
+			name:        "has api_server block",
+			expectedKey: "api_server",
+			description: "blocks should have api_server key",
+		},
+		{
+			name:        "has controller_manager block",
+			expectedKey: "controller_manager",
+			description: "blocks should have controller_manager key",
+		},
+		{
+			name:        "has scheduler block",
+			expectedKey: "scheduler",
+			description: "blocks should have scheduler key",
+		},
 	}
 
 	for _, tt := range tests {
@@ -19,6 +19,7 @@ package kind
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"strings"
 
@@ -30,6 +31,9 @@ import (
 //nolint:grouper // false positive
 var ErrPortOutOfRange = errors.New("port value out of valid range")
 
+// runtimePickedHostPort makes kind publish a port on a host port picked by the container runtime.
+const runtimePickedHostPort = -1
+
 // flattenKindConfig converts a map representation of kind configuration to v1alpha4.Cluster.
 // This function processes the configuration data and returns a structured cluster configuration.
 func flattenKindConfig(kindConfig map[string]any) (*v1alpha4.Cluster, error) {
@@ -49,9 +53,9 @@ func flattenKindConfig(kindConfig map[string]any) (*v1alpha4.Cluster, error) {
 		obj.Nodes = append(obj.Nodes, node)
 	}
 
-	// Process networking configuration if present.
-	if networkingSlice := getMapSlice(kindConfig, "networking"); len(networkingSlice) > 0 {
-		networking, err := flattenKindConfigNetworking(networkingSlice[0])
+	// Process networking configuration if present, either as a single block or a list of blocks.
+	if networkingMap := getMap(kindConfig, "networking"); networkingMap != nil {
+		networking, err := flattenKindConfigNetworking(networkingMap)
 		if err != nil {
 			return nil, fmt.Errorf("failed to flatten networking configuration: %w", err)
 		}
@@ -59,8 +63,56 @@ func flattenKindConfig(kindConfig map[string]any) (*v1alpha4.Cluster, error) {
 		obj.Networking = networking
 	}
 
+	// Enable the containerd registry host directory when mirrors are configured;
+	// the hosts.toml files themselves are written into the nodes after creation.
+	mirrors := flattenRegistryMirrors(kindConfig)
+	for _, mirror := range mirrors {
+		err := mirror.validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(mirrors) > 0 {
+		obj.ContainerdConfigPatches = append(obj.ContainerdConfigPatches, containerdRegistryConfigPatch)
+	}
+
 	// Extract containerd configuration patches.
-	obj.ContainerdConfigPatches = getStringSlice(kindConfig, "containerd_config_patches")
+	obj.ContainerdConfigPatches = append(
+		obj.ContainerdConfigPatches,
+		getStringSlice(kindConfig, "containerd_config_patches")...,
+	)
+
+	// Render structured control plane component settings into a kubeadm patch.
+	clusterPatch, err := clusterConfigurationPatch(
+		getStringMap(getMap(kindConfig, "api_server"), "extra_args"),
+		getStringMap(getMap(kindConfig, "controller_manager"), "extra_args"),
+		getStringMap(getMap(kindConfig, "scheduler"), "extra_args"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render control plane configuration: %w", err)
+	}
+
+	if clusterPatch != "" {
+		obj.KubeadmConfigPatches = append(obj.KubeadmConfigPatches, clusterPatch)
+	}
+
+	// Render the cluster-wide kubelet configuration into a KubeletConfiguration patch.
+	kubeletConfig := flattenKubeletConfig(getMap(kindConfig, "kubelet_config"))
+
+	err = kubeletConfig.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeletConfigPatch, err := kubeletConfig.configurationPatch()
+	if err != nil {
+		return nil, err
+	}
+
+	if kubeletConfigPatch != "" {
+		obj.KubeadmConfigPatches = append(obj.KubeadmConfigPatches, kubeletConfigPatch)
+	}
 
 	// Process runtime configuration and normalize keys.
 	if runtimeConfig := getStringMap(kindConfig, "runtime_config"); runtimeConfig != nil {
@@ -121,8 +173,32 @@ func flattenKindConfigNodes(nodeConfig map[string]any) (v1alpha4.Node, error) {
 		obj.ExtraPortMappings = append(obj.ExtraPortMappings, portMapping)
 	}
 
+	// Node-level kubelet_config overrides are passed as flags; explicit extra args win over them.
+	kubeletConfig := flattenKubeletConfig(getMap(nodeConfig, "kubelet_config"))
+
+	err := kubeletConfig.validate()
+	if err != nil {
+		return obj, err
+	}
+
+	kubeletArgs := kubeletConfig.extraArgs()
+	maps.Copy(kubeletArgs, getStringMap(getMap(nodeConfig, "kubelet"), "extra_args"))
+
+	// Render structured kubelet settings ahead of the user patches so the latter take precedence.
+	kubeletPatch, err := kubeletExtraArgsPatch(kubeletArgs)
+	if err != nil {
+		return obj, fmt.Errorf("failed to render kubelet configuration: %w", err)
+	}
+
+	if kubeletPatch != "" {
+		obj.KubeadmConfigPatches = append(obj.KubeadmConfigPatches, kubeletPatch)
+	}
+
 	// Extract kubeadm configuration patches.
-	obj.KubeadmConfigPatches = getStringSlice(nodeConfig, "kubeadm_config_patches")
+	obj.KubeadmConfigPatches = append(
+		obj.KubeadmConfigPatches,
+		getStringSlice(nodeConfig, "kubeadm_config_patches")...,
+	)
 
 	return obj, nil
 }
@@ -235,6 +311,10 @@ func flattenKindConfigExtraPortMappings(
 		obj.ContainerPort = int32(containerPort) // #nosec G115 -- validated range check
 	}
 
+	// Let the runtime pick a free host port, kind would otherwise probe for one on the
+	// machine running Terraform, which races with parallel creates and ignores remote hosts.
+	obj.HostPort = runtimePickedHostPort
+
 	// Validate and set host port within int32 range.
 	if hostPort := getInt(portMappingConfig, "host_port"); hostPort != 0 {
 		if hostPort < math.MinInt32 || hostPort > math.MaxInt32 {
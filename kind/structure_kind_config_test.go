@@ -254,6 +254,74 @@ func TestFlattenKindConfig(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "cluster config with single networking block",
+			input: map[string]any{
+				"kind":        testClusterKind,
+				"api_version": testAPIVersion,
+				"networking": map[string]any{
+					"api_server_address": testAPIServerAddress,
+					"pod_subnet":         testPodSubnet,
+				},
+			},
+			validator: func(t *testing.T, result *v1alpha4.Cluster) {
+				t.Helper()
+				assert.Equal(
+					t,
+					testAPIServerAddress,
+					result.Networking.APIServerAddress,
+					"API server address should be set correctly",
+				)
+				assert.Equal(
+					t,
+					testPodSubnet,
+					result.Networking.PodSubnet,
+					"pod subnet should be set correctly",
+				)
+			},
+		},
+		{
+			name: "cluster config with control plane component settings",
+			input: map[string]any{
+				"kind":        testClusterKind,
+				"api_version": testAPIVersion,
+				"api_server": map[string]any{
+					"extra_args": map[string]any{"enable-admission-plugins": "NodeRestriction"},
+					"cert_sans":  []any{"kind.local"},
+				},
+				"scheduler": map[string]any{
+					"extra_args": map[string]any{"v": "4"},
+				},
+			},
+			validator: func(t *testing.T, result *v1alpha4.Cluster) {
+				t.Helper()
+				require.Len(t, result.KubeadmConfigPatches, 1, "should render one patch")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "kind: ClusterConfiguration")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "enable-admission-plugins: NodeRestriction")
+				assert.NotContains(
+					t,
+					result.KubeadmConfigPatches[0],
+					"certSANs",
+					"cert SANs are rendered together with the resource-level SANs",
+				)
+			},
+		},
+		{
+			name: "cluster config with kubelet configuration",
+			input: map[string]any{
+				"kind":        testClusterKind,
+				"api_version": testAPIVersion,
+				"kubelet_config": map[string]any{
+					"max_pods": 50,
+				},
+			},
+			validator: func(t *testing.T, result *v1alpha4.Cluster) {
+				t.Helper()
+				require.Len(t, result.KubeadmConfigPatches, 1, "should render one patch")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "kind: KubeletConfiguration")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "maxPods: 50")
+			},
+		},
 		{
 			name: "cluster config with containerd patches",
 			input: map[string]any{
@@ -377,6 +445,40 @@ func TestFlattenKindConfigNodes(t *testing.T) {
 				assert.Equal(t, testNodeImage, result.Image, "image should be set correctly")
 			},
 		},
+		{
+			name: "node with kubelet extra args ahead of user patches",
+			input: map[string]any{
+				"role": testWorkerRole,
+				"kubelet": map[string]any{
+					"extra_args": map[string]any{"max-pods": "50"},
+				},
+				"kubeadm_config_patches": []any{"kind: JoinConfiguration\n"},
+			},
+			validator: func(t *testing.T, result v1alpha4.Node) {
+				t.Helper()
+				require.Len(t, result.KubeadmConfigPatches, 2, "should have rendered and user patches")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "kubeletExtraArgs")
+				assert.Equal(t, "kind: JoinConfiguration\n", result.KubeadmConfigPatches[1])
+			},
+		},
+		{
+			name: "node with kubelet configuration overrides",
+			input: map[string]any{
+				"role": testWorkerRole,
+				"kubelet_config": map[string]any{
+					"max_pods": 20,
+				},
+				"kubelet": map[string]any{
+					"extra_args": map[string]any{"v": "2"},
+				},
+			},
+			validator: func(t *testing.T, result v1alpha4.Node) {
+				t.Helper()
+				require.Len(t, result.KubeadmConfigPatches, 1, "should render one patch")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "max-pods: \"20\"")
+				assert.Contains(t, result.KubeadmConfigPatches[0], "v: \"2\"")
+			},
+		},
 		{
 			name: "node with custom labels",
 			input: map[string]any{
@@ -774,6 +876,37 @@ func TestFlattenKindConfigExtraPortMappings(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "runtime picked host port",
+			input: map[string]any{
+				"container_port": testContainerPort,
+			},
+			validator: func(t *testing.T, result v1alpha4.PortMapping) {
+				t.Helper()
+				assert.Equal(
+					t,
+					int32(runtimePickedHostPort),
+					result.HostPort,
+					"an unset host port should be picked by the runtime",
+				)
+			},
+		},
+		{
+			name: "zero host port",
+			input: map[string]any{
+				"container_port": testContainerPort,
+				"host_port":      0,
+			},
+			validator: func(t *testing.T, result v1alpha4.PortMapping) {
+				t.Helper()
+				assert.Equal(
+					t,
+					int32(runtimePickedHostPort),
+					result.HostPort,
+					"a zero host port should be picked by the runtime",
+				)
+			},
+		},
 		{
 			name: "port mapping with listen address",
 			input: map[string]any{
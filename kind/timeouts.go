@@ -0,0 +1,92 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// timeoutCreate is the timeouts attribute bounding cluster creation.
+	timeoutCreate = "create"
+	// timeoutUpdate is the timeouts attribute bounding in-place updates, such as resuming a cluster.
+	timeoutUpdate = "update"
+	// timeoutDelete is the timeouts attribute bounding cluster deletion.
+	timeoutDelete = "delete"
+)
+
+// ErrInvalidTimeout is returned when a timeouts value is not a positive duration.
+//
+//nolint:grouper // false positive
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+// timeoutsBlock returns the timeouts block schema.
+// Timeouts only bound future operations, so they are updated in place.
+func timeoutsBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "Operation timeouts, as Go durations (ex: 10m).",
+		Attributes: map[string]schema.Attribute{
+			timeoutCreate: schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Timeout for waiting on the cluster during create, shared by the control plane, bootstrap_manifests and wait_for waits. Defaults to %s.", defaultTimeout),
+			},
+			timeoutUpdate: schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Timeout for waiting on the APIServer when resuming a stopped cluster. Defaults to %s.", defaultTimeout),
+			},
+			timeoutDelete: schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Timeout for deleting the cluster. Defaults to %s.", defaultTimeout),
+			},
+		},
+	}
+}
+
+// operationTimeout returns the timeout configured for operation,
+// or defaultTimeout when it is not set.
+func operationTimeout(timeouts types.Object, operation string) (time.Duration, error) {
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return defaultTimeout, nil
+	}
+
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrInvalidTimeout, operation, err)
+	}
+
+	if timeout <= 0 {
+		return 0, fmt.Errorf("%w: %s must be positive, got %q", ErrInvalidTimeout, operation, value.ValueString())
+	}
+
+	return timeout, nil
+}
+
+// remainingTimeout returns the part of an operation timeout left before deadline.
+// It never returns zero, which kind reads as no wait, so a spent budget fails the next wait right away.
+func remainingTimeout(deadline time.Time) time.Duration {
+	return max(time.Until(deadline), time.Millisecond)
+}
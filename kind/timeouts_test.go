@@ -0,0 +1,101 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationTimeout(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		timeoutCreate: types.StringType,
+		timeoutDelete: types.StringType,
+	}
+
+	timeouts := func(create types.String) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			timeoutCreate: create,
+			timeoutDelete: types.StringNull(),
+		})
+	}
+
+	tests := []struct {
+		timeouts types.Object
+		name     string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "null block uses default",
+			timeouts: types.ObjectNull(attrTypes),
+			expected: defaultTimeout,
+		},
+		{
+			name:     "unset value uses default",
+			timeouts: timeouts(types.StringNull()),
+			expected: defaultTimeout,
+		},
+		{
+			name:     "unknown value uses default",
+			timeouts: timeouts(types.StringUnknown()),
+			expected: defaultTimeout,
+		},
+		{
+			name:     "parses duration",
+			timeouts: timeouts(types.StringValue("10m")),
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "rejects invalid duration",
+			timeouts: timeouts(types.StringValue("ten minutes")),
+			wantErr:  true,
+		},
+		{
+			name:     "rejects non-positive duration",
+			timeouts: timeouts(types.StringValue("0s")),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, err := operationTimeout(tt.timeouts, timeoutCreate)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTimeout)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestRemainingTimeout(t *testing.T) {
+	remaining := remainingTimeout(time.Now().Add(time.Minute))
+	assert.Greater(t, remaining, 59*time.Second)
+	assert.LessOrEqual(t, remaining, time.Minute)
+
+	assert.Equal(t, time.Millisecond, remainingTimeout(time.Now().Add(-time.Minute)), "spent budget")
+}
@@ -0,0 +1,146 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const (
+	// trustedCABundlePath is the system trust store entry of the trusted CA certificates inside the nodes.
+	trustedCABundlePath = "/usr/local/share/ca-certificates/terraform-provider-kind.crt"
+	// containerdDefaultHostDir holds the containerd host configuration used for every registry
+	// that has no directory of its own.
+	containerdDefaultHostDir = containerdCertsDir + "/_default"
+)
+
+// ErrInvalidCACertificate is returned when a trusted_ca_certificates entry is not a PEM certificate.
+//
+//nolint:grouper // false positive
+var ErrInvalidCACertificate = errors.New("invalid trusted CA certificate")
+
+// validateCACertificate checks that a trusted_ca_certificates entry holds one or more
+// PEM encoded certificates and nothing else.
+func validateCACertificate(data string) error {
+	rest := []byte(data)
+	count := 0
+
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("%w: unexpected PEM block %s", ErrInvalidCACertificate, block.Type)
+		}
+
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidCACertificate, err)
+		}
+
+		count++
+	}
+
+	if count == 0 || strings.TrimSpace(string(rest)) != "" {
+		return fmt.Errorf("%w: expected PEM encoded certificates", ErrInvalidCACertificate)
+	}
+
+	return nil
+}
+
+// trustedCAFiles returns the files, keyed by in-node path, installing the certificates into
+// the system trust store and into the default containerd host configuration.
+func trustedCAFiles(certificates []string) map[string]string {
+	if len(certificates) == 0 {
+		return nil
+	}
+
+	var bundle strings.Builder
+
+	for _, certificate := range certificates {
+		bundle.WriteString(strings.TrimSpace(certificate))
+		bundle.WriteString("\n")
+	}
+
+	caPath := path.Join(containerdDefaultHostDir, registryCAFile)
+
+	return map[string]string{
+		trustedCABundlePath: bundle.String(),
+		caPath:              bundle.String(),
+		path.Join(containerdDefaultHostDir, registryHostsFile): "ca = " + strconv.Quote(caPath) + "\n",
+	}
+}
+
+// trustedCADir returns the host directory the trusted CA files of a cluster on a local
+// runtime are mounted from. It has to outlive the create, as restarted nodes mount it again.
+func trustedCADir(clusterName string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating the trusted CA directory: %w", err)
+	}
+
+	return filepath.Join(cacheDir, "terraform-provider-kind", "trusted-ca", clusterName), nil
+}
+
+// trustedCAMounts returns the read-only mounts of the trusted CA files written into dir,
+// so that containerd trusts the certificates before kubeadm pulls any image.
+func trustedCAMounts(dir string, files map[string]string) []v1alpha4.Mount {
+	mounts := make([]v1alpha4.Mount, 0, len(files))
+
+	for _, nodePath := range slices.Sorted(maps.Keys(files)) {
+		mounts = append(mounts, v1alpha4.Mount{
+			HostPath:      filepath.Join(dir, path.Base(nodePath)),
+			ContainerPath: nodePath,
+			Readonly:      true,
+		})
+	}
+
+	return mounts
+}
+
+// writeTrustedCAFiles writes the trusted CA files into dir, named after their in-node path.
+func writeTrustedCAFiles(dir string, files map[string]string) error {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	for nodePath, content := range files {
+		err = os.WriteFile(filepath.Join(dir, path.Base(nodePath)), []byte(content), 0o600)
+		if err != nil {
+			return fmt.Errorf("writing trusted CA file: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+   Copyright 2026 Sumicare
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kind
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func testCACertificate(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Corporate Proxy CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestValidateCACertificate(t *testing.T) {
+	certificate := testCACertificate(t)
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "single certificate", data: certificate},
+		{name: "bundle", data: certificate + "\n" + certificate},
+		{name: "empty", data: "", wantErr: true},
+		{name: "not pem", data: "corporate ca", wantErr: true},
+		{name: "trailing garbage", data: certificate + "garbage", wantErr: true},
+		{
+			name:    "private key",
+			data:    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})),
+			wantErr: true,
+		},
+		{
+			name:    "corrupt certificate",
+			data:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("corrupt")})),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCACertificate(tt.data)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidCACertificate)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTrustedCAFiles(t *testing.T) {
+	assert.Nil(t, trustedCAFiles(nil))
+
+	files := trustedCAFiles([]string{"-----BEGIN CERTIFICATE-----\na\n-----END CERTIFICATE-----\n\n", "b"})
+
+	bundle := "-----BEGIN CERTIFICATE-----\na\n-----END CERTIFICATE-----\nb\n"
+	assert.Equal(t, map[string]string{
+		"/usr/local/share/ca-certificates/terraform-provider-kind.crt": bundle,
+		"/etc/containerd/certs.d/_default/ca.crt":                      bundle,
+		"/etc/containerd/certs.d/_default/hosts.toml":                  "ca = \"/etc/containerd/certs.d/_default/ca.crt\"\n",
+	}, files)
+}
+
+func TestWriteTrustedCAFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "trusted-ca")
+	files := trustedCAFiles([]string{testCACertificate(t)})
+
+	require.NoError(t, writeTrustedCAFiles(dir, files))
+
+	for _, mount := range trustedCAMounts(dir, files) {
+		content, err := os.ReadFile(mount.HostPath)
+		require.NoError(t, err)
+		assert.Equal(t, files[mount.ContainerPath], string(content))
+		assert.True(t, mount.Readonly)
+	}
+}
+
+func TestBuildKindConfig_TrustedCAs(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+
+	data := &ClusterResourceModel{
+		Name:       types.StringValue("corp"),
+		KindConfig: types.ListNull(types.ObjectType{}),
+		TrustedCACertificates: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue(testCACertificate(t)),
+		}),
+	}
+
+	dir, err := trustedCADir("corp")
+	require.NoError(t, err)
+
+	mounts := trustedCAMounts(dir, trustedCAFiles(listToStringSlice(data.TrustedCACertificates)))
+
+	t.Run("local runtime mounts the certificates into every node", func(t *testing.T) {
+		kindConfig, err := buildKindConfig(t.Context(), data, "")
+		require.NoError(t, err)
+		require.NotNil(t, kindConfig)
+		assert.Equal(t, []string{containerdRegistryConfigPatch}, kindConfig.ContainerdConfigPatches)
+		require.Len(t, kindConfig.Nodes, 1)
+		assert.Equal(t, v1alpha4.ControlPlaneRole, kindConfig.Nodes[0].Role)
+		assert.Equal(t, mounts, kindConfig.Nodes[0].ExtraMounts)
+	})
+
+	t.Run("remote runtime writes them after creation", func(t *testing.T) {
+		kindConfig, err := buildKindConfig(t.Context(), data, "10.0.0.5")
+		require.NoError(t, err)
+		assert.Equal(t, []string{containerdRegistryConfigPatch}, kindConfig.ContainerdConfigPatches)
+		assert.Empty(t, kindConfig.Nodes)
+	})
+}
@@ -109,6 +109,26 @@ func getStringSlice(m map[string]any, key string) []string {
 	return nil
 }
 
+// getMap safely extracts a nested block as map[string]any from a map.
+// Single nested blocks are stored as a map while list blocks are stored as a slice of maps;
+// for the latter the first element is returned. Returns nil if the key doesn't exist.
+func getMap(m map[string]any, key string) map[string]any {
+	val, exists := m[key]
+	if !exists || val == nil {
+		return nil
+	}
+
+	if nested, isMap := val.(map[string]any); isMap {
+		return nested
+	}
+
+	if slice := getMapSlice(m, key); len(slice) > 0 {
+		return slice[0]
+	}
+
+	return nil
+}
+
 // getMapSlice safely extracts a []map[string]any from a map.
 // Returns nil if the key doesn't exist or the value is not a slice of maps.
 func getMapSlice(m map[string]any, key string) []map[string]any {
@@ -155,27 +175,13 @@ func parseKindConfigFromFramework(
 	_ context.Context,
 	kindConfigList types.List,
 ) (*v1alpha4.Cluster, error) {
+	// Convert to map[string]any for the existing flattener
+	configMap := kindConfigMapFromFramework(kindConfigList)
 	//nolint:nilnil // false positive
-	if kindConfigList.IsNull() || len(kindConfigList.Elements()) == 0 {
-		return nil, nil
-	}
-
-	// Get the first (and only) kind_config block
-	elements := kindConfigList.Elements()
-	//nolint:nilnil // false positive
-	if len(elements) == 0 {
-		return nil, nil
-	}
-
-	kindConfigObj, ok := elements[0].(types.Object)
-	//nolint:nilnil // false positive
-	if !ok {
+	if configMap == nil {
 		return nil, nil
 	}
 
-	// Convert to map[string]any for the existing flattener
-	configMap := objectToMap(kindConfigObj)
-
 	// Use existing flattener
 	cluster, err := flattenKindConfig(configMap)
 	if err != nil {
@@ -185,6 +191,17 @@ func parseKindConfigFromFramework(
 	return cluster, nil
 }
 
+// kindConfigMapFromFramework converts the first (and only) kind_config block to map[string]any.
+// Returns nil if the block is not set.
+func kindConfigMapFromFramework(kindConfigList types.List) map[string]any {
+	kindConfigObj, ok := firstObject(kindConfigList)
+	if !ok {
+		return nil
+	}
+
+	return objectToMap(kindConfigObj)
+}
+
 // objectToMap converts a Framework Object to map[string]any.
 func objectToMap(obj types.Object) map[string]any {
 	if obj.IsNull() || obj.IsUnknown() {
@@ -211,6 +228,10 @@ func attrValueToAny(value attr.Value) any {
 	switch val := value.(type) {
 	case types.String:
 		return val.ValueString()
+	case basetypes.StringValuable:
+		stringValue, _ := val.ToStringValue(context.Background())
+
+		return stringValue.ValueString()
 	case types.Bool:
 		return val.ValueBool()
 	case types.Int64:
@@ -282,3 +303,16 @@ func mapToMap(m basetypes.MapValue) map[string]any {
 
 	return result
 }
+
+// listToStringSlice converts a Framework List of strings to []string.
+func listToStringSlice(list basetypes.ListValue) []string {
+	result := make([]string, 0, len(list.Elements()))
+
+	for _, elem := range listToSlice(list) {
+		if s, isString := elem.(string); isString {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
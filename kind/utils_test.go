@@ -21,7 +21,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,6 +39,29 @@ const (
 
 var emptyMap = make(map[string]any)
 
+// resourceTestConfig returns a configuration of the resource with the given attribute values,
+// all other attributes are null.
+func resourceTestConfig(t *testing.T, res resource.Resource, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	resp := &resource.SchemaResponse{}
+	res.Schema(t.Context(), resource.SchemaRequest{}, resp)
+
+	objectType, ok := resp.Schema.Type().TerraformType(t.Context()).(tftypes.Object)
+	require.True(t, ok)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, set := values[name]; set {
+			attributes[name] = value
+		}
+	}
+
+	return tfsdk.Config{Schema: resp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+}
+
 // mustParseBigFloat parses a string to big.Float or panics on error.
 //
 //nolint:revive // Test helper function
@@ -183,6 +209,52 @@ func TestGetMapSlice(t *testing.T) {
 	}
 }
 
+func TestGetMap(t *testing.T) {
+	tests := []struct {
+		input    map[string]any
+		expected map[string]any
+		name     string
+		key      string
+	}{
+		{
+			name:     "extracts single nested block",
+			input:    map[string]any{testKey: map[string]any{"a": 1}},
+			key:      testKey,
+			expected: map[string]any{"a": 1},
+		},
+		{
+			name:     "extracts first element of list block",
+			input:    map[string]any{testKey: []any{map[string]any{"a": 1}, map[string]any{"b": 2}}},
+			key:      testKey,
+			expected: map[string]any{"a": 1},
+		},
+		{
+			name:     "empty list returns nil",
+			input:    map[string]any{testKey: make([]any, 0)},
+			key:      testKey,
+			expected: nil,
+		},
+		{
+			name:     "missing key returns nil",
+			input:    emptyMap,
+			key:      testKey,
+			expected: nil,
+		},
+		{
+			name:     "wrong type returns nil",
+			input:    map[string]any{testKey: testStringValue},
+			key:      testKey,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getMap(tt.input, tt.key))
+		})
+	}
+}
+
 func TestGetStringMap(t *testing.T) {
 	tests := []struct {
 		input    map[string]any